@@ -0,0 +1,64 @@
+package arclength
+
+import (
+	"math"
+	"testing"
+)
+
+const EPSILON = 0.0001
+
+func TestIntegrate(t *testing.T) {
+	// Polynomial of degree 9 is integrated exactly by a single Gauss rule
+	poly := func(t float32) float32 { return 10 * t * t * t * t * t * t * t * t * t }
+	if got := Gauss(poly, 0, 1); math.Abs(float64(got-1)) > EPSILON {
+		t.Errorf("Gauss of 10t^9 from 0 to 1 failed: got %f, want 1", got)
+	}
+
+	// Length of a quarter unit circle with constant speed
+	circle := func(t float32) float32 { return math.Pi / 2 }
+	if got := Integrate(circle, 0, 1, DefaultTolerance); math.Abs(float64(got)-math.Pi/2) > EPSILON {
+		t.Errorf("Integrate of constant failed: got %f, want %f", got, math.Pi/2)
+	}
+	kink := func(t float32) float32 { return float32(math.Abs(float64(t) - 0.3)) }
+	if got := Integrate(kink, 0, 1, DefaultTolerance); math.Abs(float64(got)-0.29) > EPSILON {
+		t.Errorf("Integrate of |t-0.3| failed: got %f, want 0.29", got)
+	}
+	sqrt := func(t float32) float32 { return float32(math.Sqrt(float64(t))) }
+	if got := Integrate(sqrt, 0, 1, DefaultTolerance); math.Abs(float64(got)-2.0/3.0) > EPSILON {
+		t.Errorf("Integrate of sqrt(t) failed: got %f, want %f", got, 2.0/3.0)
+	}
+	if got := Integrate(sqrt, 0.5, 0.5, DefaultTolerance); got != 0 {
+		t.Errorf("Integrate of empty interval should be 0, got %f", got)
+	}
+}
+
+func TestTable(t *testing.T) {
+	// Arc length of speed 2t is t^2, so the parameter at distance d is sqrt(d)
+	table := NewTable(func(t float32) float32 { return 2 * t }, 4)
+	if got := table.Length(); math.Abs(float64(got-1)) > EPSILON {
+		t.Errorf("wrong table length: got %f, want 1", got)
+	}
+	for _, d := range []float32{0, 0.01, 0.1, 0.25, 0.3, 0.5, 0.9, 1} {
+		want := float32(math.Sqrt(float64(d)))
+		if got := table.Param(d); math.Abs(float64(got-want)) > EPSILON {
+			t.Errorf("Param(%f) failed: got %f, want %f", d, got, want)
+		}
+	}
+	if got := table.Param(-1); got != 0 {
+		t.Errorf("Param of negative distance should be 0, got %f", got)
+	}
+	if got := table.Param(2); got != 1 {
+		t.Errorf("Param of distance beyond length should be 1, got %f", got)
+	}
+
+	params := table.UniformParams(5, nil)
+	if len(params) != 5 {
+		t.Fatalf("expected 5 params, got %d", len(params))
+	}
+	for i, p := range params {
+		want := float32(math.Sqrt(float64(i) / 4))
+		if math.Abs(float64(p-want)) > EPSILON {
+			t.Errorf("uniform param %d failed: got %f, want %f", i, p, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package bezier2
 
 import (
+	"math"
 	"testing"
 
 	"github.com/ungerik/go3d/vec2"
@@ -43,3 +44,58 @@ func TestTangent(t *testing.T) {
 		t.Errorf("cubic bezier tangent at t=0.75 failed, got %v, want %v", got, want)
 	}
 }
+
+func TestDerivative(t *testing.T) {
+	b := T{vec2.T{0, 0}, vec2.T{1, 1}, vec2.T{2, 1}, vec2.T{3, 0}}
+	for _, u := range []float32{0, 0.25, 0.5, 0.75, 1} {
+		if got, want := b.Derivative(u), b.Tangent(u); got != want {
+			t.Errorf("cubic bezier derivative at t=%v failed, got %v, want %v", u, got, want)
+		}
+	}
+	// Control points coinciding with the end points
+	b = T{vec2.T{0, 0}, vec2.T{0, 0}, vec2.T{3, 0}, vec2.T{3, 0}}
+	if got, want := b.Derivative(0), (vec2.T{0, 0}); got != want {
+		t.Errorf("cubic bezier derivative at t=0 failed, got %v, want %v", got, want)
+	}
+	if got, want := b.Derivative(1), (vec2.T{0, 0}); got != want {
+		t.Errorf("cubic bezier derivative at t=1 failed, got %v, want %v", got, want)
+	}
+	if got, want := b.Derivative(0.5), (vec2.T{4.5, 0}); got != want {
+		t.Errorf("cubic bezier derivative at t=0.5 failed, got %v, want %v", got, want)
+	}
+}
+
+func TestLength(t *testing.T) {
+	b := T{vec2.T{0, 0}, vec2.T{1, 1}, vec2.T{2, 1}, vec2.T{3, 0}}
+	// Reference value from numerical integration with high precision
+	const want = 3.4433807240800447
+	if got := b.Length(1); math.Abs(float64(got)-want) > 0.0001 {
+		t.Errorf("cubic bezier length failed, got %f, want %f", got, want)
+	}
+	// The spline is symmetric, so half of it is reached at t=0.5
+	if got := b.Length(0.5); math.Abs(float64(got)-want/2) > 0.0001 {
+		t.Errorf("cubic bezier length at t=0.5 failed, got %f, want %f", got, want/2)
+	}
+	if got := b.Length(0); got != 0 {
+		t.Errorf("cubic bezier length at t=0 should be 0, got %f", got)
+	}
+
+	line := T{vec2.T{0, 0}, vec2.T{1, 0}, vec2.T{2, 0}, vec2.T{3, 0}}
+	if got := line.LengthEps(1, 1e-6); math.Abs(float64(got)-3) > 0.0001 {
+		t.Errorf("straight cubic bezier length failed, got %f, want 3", got)
+	}
+}
+
+func TestArcLengthTable(t *testing.T) {
+	b := T{vec2.T{0, 0}, vec2.T{1, 1}, vec2.T{2, 1}, vec2.T{3, 0}}
+	table := b.ArcLengthTable(16)
+	if got, want := table.Length(), b.Length(1); math.Abs(float64(got-want)) > 0.0001 {
+		t.Errorf("table length failed, got %f, want %f", got, want)
+	}
+	for i, param := range table.UniformParams(5, nil) {
+		want := table.Length() * float32(i) / 4
+		if got := b.Length(param); math.Abs(float64(got-want)) > 0.0001 {
+			t.Errorf("uniform parameter %f has length %f, want %f", param, got, want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package bezier2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/vec2"
+)
+
+func TestNearest(t *testing.T) {
+	for _, b := range testCurves {
+		for _, want := range []float32{0, 0.1, 0.37, 0.5, 0.81, 1} {
+			p := b.Point(want)
+			_, point, distance := b.Nearest(&p)
+			if distance > EPSILON || !point.PracticallyEquals(&p, EPSILON) {
+				t.Errorf("nearest point of %v on the curve at t=%f failed, got %v with distance %f", p, want, point, distance)
+			}
+		}
+	}
+
+	b := testCurves[0]
+	p := vec2.T{1.5, 2}
+	param, point, distance := b.Nearest(&p)
+	if abs(param-0.5) > EPSILON || abs(distance-1.25) > EPSILON || !point.PracticallyEquals(&vec2.T{1.5, 0.75}, EPSILON) {
+		t.Errorf("nearest point of %v failed, got t=%f, %v, distance %f", p, param, point, distance)
+	}
+	p = vec2.T{-1, -1}
+	if param, point, _ := b.Nearest(&p); param != 0 || point != b.P0 {
+		t.Errorf("nearest point of %v should be the start point, got t=%f, %v", p, param, point)
+	}
+}
+
+func TestIntersectLine(t *testing.T) {
+	// y(t) = 3t(1-t) crosses y = 0.5 at t = (1 ± sqrt(1/3)) / 2
+	b := testCurves[0]
+	a, c := vec2.T{0, 0.5}, vec2.T{3, 0.5}
+	hits := b.IntersectLine(&a, &c, 0.0001, nil)
+	if len(hits) != 2 {
+		t.Fatalf("expected 2 intersections, got %v", hits)
+	}
+	want := [2]float64{(1 - math.Sqrt(1.0/3)) / 2, (1 + math.Sqrt(1.0/3)) / 2}
+	for i, hit := range hits {
+		if math.Abs(float64(hit.T)-want[i]) > 0.001 {
+			t.Errorf("intersection %d failed, got t=%f, want %f", i, hit.T, want[i])
+		}
+		if abs(hit.Point[1]-0.5) > 0.001 || abs(hit.Point[0]-3*hit.U) > 0.001 {
+			t.Errorf("intersection %d is not on the line: %v", i, hit)
+		}
+	}
+
+	// Line through both end points
+	a, c = vec2.T{-1, 0}, vec2.T{4, 0}
+	hits = b.IntersectLine(&a, &c, 0.0001, nil)
+	if len(hits) != 2 || hits[0].T != 0 || hits[1].T != 1 {
+		t.Errorf("expected intersections at the end points, got %v", hits)
+	}
+
+	a, c = vec2.T{0, 2}, vec2.T{1, 2}
+	if hits = b.IntersectLine(&a, &c, 0.0001, nil); len(hits) != 0 {
+		t.Errorf("expected no intersections, got %v", hits)
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	// y(t) = 3t(1-t) and y(t) = 0.6 - 3t(1-t) with the same x(t)
+	// cross at t(1-t) = 0.1
+	a := testCurves[0]
+	b := T{vec2.T{0, 0.6}, vec2.T{1, -0.4}, vec2.T{2, -0.4}, vec2.T{3, 0.6}}
+	hits := a.Intersect(&b, 0.0001, nil)
+	if len(hits) != 2 {
+		t.Fatalf("expected 2 intersections, got %v", hits)
+	}
+	want := [2]float64{(1 - math.Sqrt(0.6)) / 2, (1 + math.Sqrt(0.6)) / 2}
+	for i, hit := range hits {
+		if math.Abs(float64(hit.T)-want[i]) > 0.001 || math.Abs(float64(hit.U)-want[i]) > 0.001 {
+			t.Errorf("intersection %d failed, got %v, want t=u=%f", i, hit, want[i])
+		}
+		pa := a.Point(hit.T)
+		pb := b.Point(hit.U)
+		if !pa.PracticallyEquals(&hit.Point, 0.001) || !pb.PracticallyEquals(&hit.Point, 0.001) {
+			t.Errorf("intersection %d is not on both curves: %v, %v, %v", i, hit.Point, pa, pb)
+		}
+	}
+
+	far := T{vec2.T{10, 10}, vec2.T{11, 11}, vec2.T{12, 11}, vec2.T{13, 10}}
+	if hits = a.Intersect(&far, 0.0001, nil); len(hits) != 0 {
+		t.Errorf("expected no intersections, got %v", hits)
+	}
+	if hits = a.Intersect(&a, 0.0001, nil); len(hits) > maxIntersections {
+		t.Errorf("intersections of identical curves should be limited, got %d", len(hits))
+	}
+}
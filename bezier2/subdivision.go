@@ -0,0 +1,108 @@
+package bezier2
+
+import (
+	"sort"
+
+	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/vec2"
+)
+
+// Split splits the cubic bezier spline at t (0,1) into two splines
+// using the de Casteljau algorithm.
+// left runs from P0 to Point(t) and right from Point(t) to P3.
+func (bez *T) Split(t float32) (left, right T) {
+	p01 := vec2.Interpolate(&bez.P0, &bez.P1, t)
+	p12 := vec2.Interpolate(&bez.P1, &bez.P2, t)
+	p23 := vec2.Interpolate(&bez.P2, &bez.P3, t)
+	p012 := vec2.Interpolate(&p01, &p12, t)
+	p123 := vec2.Interpolate(&p12, &p23, t)
+	p0123 := vec2.Interpolate(&p012, &p123, t)
+	left = T{bez.P0, p01, p012, p0123}
+	right = T{p0123, p123, p23, bez.P3}
+	return left, right
+}
+
+// SubCurve returns the part of the cubic bezier spline from t0 to t1
+// as a new spline with the parameter range (0,1).
+// If t0 is greater than t1, the returned spline runs in the opposite direction.
+func (bez *T) SubCurve(t0, t1 float32) T {
+	return T{
+		bez.blossom(t0, t0, t0),
+		bez.blossom(t0, t0, t1),
+		bez.blossom(t0, t1, t1),
+		bez.blossom(t1, t1, t1),
+	}
+}
+
+// blossom evaluates the polar form of the spline,
+// which is the de Casteljau algorithm with a different t for every level.
+func (bez *T) blossom(t0, t1, t2 float32) vec2.T {
+	p01 := vec2.Interpolate(&bez.P0, &bez.P1, t0)
+	p12 := vec2.Interpolate(&bez.P1, &bez.P2, t0)
+	p23 := vec2.Interpolate(&bez.P2, &bez.P3, t0)
+	p012 := vec2.Interpolate(&p01, &p12, t1)
+	p123 := vec2.Interpolate(&p12, &p23, t1)
+	return vec2.Interpolate(&p012, &p123, t2)
+}
+
+// Extrema appends the parameters t in the open range (0,1) to dst
+// at which the x or y component of the spline has a local minimum or maximum,
+// which are the roots of the derivative components.
+// The appended parameters are sorted in ascending order.
+func (bez *T) Extrema(dst []float32) []float32 {
+	n := len(dst)
+	for i := range bez.P0 {
+		// Derivative divided by 3 in power basis: a*t^2 + b*t + c
+		d0 := bez.P1[i] - bez.P0[i]
+		d1 := bez.P2[i] - bez.P1[i]
+		d2 := bez.P3[i] - bez.P2[i]
+		dst = quadraticRoots(d0-2*d1+d2, 2*(d1-d0), d0, dst)
+	}
+	sort.Slice(dst[n:], func(i, j int) bool { return dst[n+i] < dst[n+j] })
+	return dst
+}
+
+// BoundingBox returns the tight axis aligned bounding box of the spline,
+// which can be smaller than the bounding box of the control points.
+func (bez *T) BoundingBox() vec2.Rect {
+	rect := vec2.Rect{
+		Min: vec2.Min(&bez.P0, &bez.P3),
+		Max: vec2.Max(&bez.P0, &bez.P3),
+	}
+	var buf [4]float32
+	for _, t := range bez.Extrema(buf[:0]) {
+		p := bez.Point(t)
+		rect.Min = vec2.Min(&rect.Min, &p)
+		rect.Max = vec2.Max(&rect.Max, &p)
+	}
+	return rect
+}
+
+// quadraticRoots appends the real roots of a*t^2 + b*t + c
+// in the open range (0,1) to dst.
+func quadraticRoots(a, b, c float32, dst []float32) []float32 {
+	if a == 0 {
+		if b != 0 {
+			dst = appendInRange(dst, -c/b)
+		}
+		return dst
+	}
+	disc := b*b - 4*a*c
+	if disc < 0 {
+		return dst
+	}
+	// Numerically stable form that avoids cancellation
+	q := -0.5 * (b + math.Copysign(math.Sqrt(disc), b))
+	dst = appendInRange(dst, q/a)
+	if q != 0 && disc > 0 {
+		dst = appendInRange(dst, c/q)
+	}
+	return dst
+}
+
+func appendInRange(dst []float32, t float32) []float32 {
+	if t > 0 && t < 1 {
+		dst = append(dst, t)
+	}
+	return dst
+}
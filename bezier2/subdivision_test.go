@@ -0,0 +1,101 @@
+package bezier2
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/vec2"
+)
+
+const EPSILON = 0.0001
+
+var testCurves = []T{
+	{vec2.T{0, 0}, vec2.T{1, 1}, vec2.T{2, 1}, vec2.T{3, 0}},
+	{vec2.T{0, 0}, vec2.T{3, 2}, vec2.T{-1, 2}, vec2.T{2, 0}},
+	{vec2.T{1, 1}, vec2.T{4, -2}, vec2.T{-3, 5}, vec2.T{2, 2}},
+}
+
+func TestSplit(t *testing.T) {
+	for _, b := range testCurves {
+		left, right := b.Split(0.3)
+		for _, s := range []float32{0, 0.25, 0.5, 0.75, 1} {
+			if got, want := left.Point(s), b.Point(0.3*s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("left part of %v at %f failed, got %v, want %v", b, s, got, want)
+			}
+			if got, want := right.Point(s), b.Point(0.3+0.7*s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("right part of %v at %f failed, got %v, want %v", b, s, got, want)
+			}
+		}
+	}
+}
+
+func TestSubCurve(t *testing.T) {
+	for _, b := range testCurves {
+		sub := b.SubCurve(0.2, 0.7)
+		reversed := b.SubCurve(0.7, 0.2)
+		for _, s := range []float32{0, 0.25, 0.5, 0.75, 1} {
+			if got, want := sub.Point(s), b.Point(0.2+0.5*s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("sub curve of %v at %f failed, got %v, want %v", b, s, got, want)
+			}
+			if got, want := reversed.Point(s), b.Point(0.7-0.5*s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("reversed sub curve of %v at %f failed, got %v, want %v", b, s, got, want)
+			}
+		}
+		if whole := b.SubCurve(0, 1); whole != b {
+			t.Errorf("sub curve from 0 to 1 should equal the curve, got %v, want %v", whole, b)
+		}
+	}
+}
+
+func TestExtrema(t *testing.T) {
+	b := testCurves[0]
+	if got := b.Extrema(nil); len(got) != 1 || got[0] != 0.5 {
+		t.Errorf("extrema of symmetric curve failed, got %v, want [0.5]", got)
+	}
+
+	for _, b := range testCurves[1:] {
+		extrema := b.Extrema(nil)
+		if len(extrema) == 0 {
+			t.Errorf("curve %v should have extrema", b)
+		}
+		for i, e := range extrema {
+			if i > 0 && extrema[i-1] > e {
+				t.Errorf("extrema of %v are not sorted: %v", b, extrema)
+			}
+			d := b.Tangent(e)
+			if abs(d[0]) > EPSILON && abs(d[1]) > EPSILON {
+				t.Errorf("derivative of %v at extremum %f is not zero in any component: %v", b, e, d)
+			}
+		}
+	}
+
+	line := T{vec2.T{0, 0}, vec2.T{1, 1}, vec2.T{2, 2}, vec2.T{3, 3}}
+	if got := line.Extrema(nil); len(got) != 0 {
+		t.Errorf("monotonic curve should have no extrema, got %v", got)
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	b := testCurves[0]
+	rect := b.BoundingBox()
+	if want := (vec2.Rect{Min: vec2.T{0, 0}, Max: vec2.T{3, 0.75}}); rect != want {
+		t.Errorf("bounding box failed, got %v, want %v", rect, want)
+	}
+
+	for _, b := range testCurves {
+		rect := b.BoundingBox()
+		for s := float32(0); s <= 1; s += 1.0 / 64 {
+			p := b.Point(s)
+			if p[0] < rect.Min[0]-EPSILON || p[0] > rect.Max[0]+EPSILON ||
+				p[1] < rect.Min[1]-EPSILON || p[1] > rect.Max[1]+EPSILON {
+				t.Errorf("point %v of %v is outside of bounding box %v", p, b, rect)
+			}
+		}
+	}
+}
+
+func abs(x float32) float32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
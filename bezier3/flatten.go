@@ -0,0 +1,43 @@
+package bezier3
+
+import (
+	"github.com/ungerik/go3d/vec3"
+)
+
+// MaxFlattenDepth limits the recursive subdivision of Flatten,
+// so at most 2^MaxFlattenDepth line segments are created per spline.
+const MaxFlattenDepth = 16
+
+// Flatten approximates the cubic bezier spline with a polyline
+// by recursive subdivision until every part is flat enough.
+// The points of the polyline are appended to dst, starting with P0 and ending with P3.
+// The distance between any point of the spline and the polyline is at most tolerance,
+// unless a part needs more than MaxFlattenDepth subdivisions.
+// No memory is allocated if dst has enough capacity.
+func (bez *T) Flatten(tolerance float32, dst []vec3.T) []vec3.T {
+	dst = append(dst, bez.P0)
+	return bez.flatten(16*tolerance*tolerance, MaxFlattenDepth, dst)
+}
+
+func (bez *T) flatten(limit float32, depth int, dst []vec3.T) []vec3.T {
+	if depth == 0 || bez.flatness() <= limit {
+		return append(dst, bez.P3)
+	}
+	left, right := bez.Split(0.5)
+	dst = left.flatten(limit, depth-1, dst)
+	return right.flatten(limit, depth-1, dst)
+}
+
+// flatness returns 16 times the squared upper bound of the distance
+// between the spline and the line from P0 to P3.
+// The difference of both is t*(1-t)*((1-t)*u + t*v) with the u and v below,
+// and t*(1-t) is at most 1/4.
+func (bez *T) flatness() float32 {
+	var sum float32
+	for i := range bez.P0 {
+		u := 3*bez.P1[i] - 2*bez.P0[i] - bez.P3[i]
+		v := 3*bez.P2[i] - bez.P0[i] - 2*bez.P3[i]
+		sum += max(u*u, v*v)
+	}
+	return sum
+}
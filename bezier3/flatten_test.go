@@ -0,0 +1,72 @@
+package bezier3
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/vec3"
+)
+
+var testCurves = []T{
+	{vec3.T{0, 0, 0}, vec3.T{1, 1, 0}, vec3.T{2, 1, 1}, vec3.T{3, 0, 1}},
+	{vec3.T{0, 0, 0}, vec3.T{3, 2, -1}, vec3.T{-1, 2, 2}, vec3.T{2, 0, 0}},
+}
+
+func TestFlatten(t *testing.T) {
+	for _, b := range testCurves {
+		for _, tolerance := range []float32{0.1, 0.01, 0.001} {
+			points := b.Flatten(tolerance, nil)
+			if points[0] != b.P0 || points[len(points)-1] != b.P3 {
+				t.Errorf("polyline of %v does not start and end at the end points: %v", b, points)
+			}
+			for s := float32(0); s <= 1; s += 1.0 / 256 {
+				p := b.Point(s)
+				if d := polylineDistance(points, &p); d > tolerance+EPSILON {
+					t.Errorf("point %v of %v has distance %f from the polyline with tolerance %f", p, b, d, tolerance)
+				}
+			}
+		}
+		coarse := b.Flatten(0.1, nil)
+		fine := b.Flatten(0.001, nil)
+		if len(fine) <= len(coarse) {
+			t.Errorf("smaller tolerance should create more points: %d <= %d", len(fine), len(coarse))
+		}
+	}
+
+	line := T{vec3.T{0, 0, 0}, vec3.T{1, 1, 1}, vec3.T{2, 2, 2}, vec3.T{3, 3, 3}}
+	if points := line.Flatten(0.001, nil); len(points) != 2 {
+		t.Errorf("straight curve should be flattened to a single line segment, got %v", points)
+	}
+}
+
+func TestFlattenAppends(t *testing.T) {
+	b := testCurves[1]
+	dst := make([]vec3.T, 1, 1024)
+	dst = b.Flatten(0.01, dst)
+	if dst[0] != (vec3.T{}) || dst[1] != b.P0 {
+		t.Errorf("Flatten should append to dst, got %v", dst[:2])
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		dst = b.Flatten(0.01, dst[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("Flatten into dst with enough capacity should not allocate, got %f allocations", allocs)
+	}
+}
+
+// polylineDistance returns the distance of p from the nearest segment of the polyline.
+func polylineDistance(points []vec3.T, p *vec3.T) float32 {
+	nearest := float32(-1)
+	for i := 1; i < len(points); i++ {
+		ab := vec3.Sub(&points[i], &points[i-1])
+		ap := vec3.Sub(p, &points[i-1])
+		s := float32(0)
+		if l := ab.LengthSqr(); l > 0 {
+			s = max(0, min(1, vec3.Dot(&ap, &ab)/l))
+		}
+		q := vec3.Interpolate(&points[i-1], &points[i], s)
+		if d := q.Sub(p).Length(); nearest < 0 || d < nearest {
+			nearest = d
+		}
+	}
+	return nearest
+}
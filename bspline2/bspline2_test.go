@@ -0,0 +1,97 @@
+package bspline2
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/vec2"
+)
+
+const EPSILON = 0.0001
+
+func point(x, y float32) vec2.T {
+	var p vec2.T
+	p[0], p[1] = x, y
+	return p
+}
+
+var testPoints = []vec2.T{point(0, 0), point(1, 2), point(3, 2.5), point(4, 0), point(4.2, -3), point(2, -4)}
+
+func TestPoint(t *testing.T) {
+	spline := T{Points: testPoints}
+	if spline.Segments() != 3 {
+		t.Errorf("wrong number of segments: %d", spline.Segments())
+	}
+	// Points of the B-spline at the knots are (p[i] + 4*p[i+1] + p[i+2]) / 6
+	for i := 0; i <= spline.Segments(); i++ {
+		want := testPoints[i+1].Scaled(4)
+		want.Add(&testPoints[i]).Add(&testPoints[i+2]).Scale(1.0 / 6.0)
+		if got := spline.Point(float32(i)); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("point at knot %d failed: got %v, want %v", i, got, want)
+		}
+		// Tangents at the knots are (p[i+2] - p[i]) / 2
+		wantTangent := vec2.Sub(&testPoints[i+2], &testPoints[i])
+		wantTangent.Scale(0.5)
+		if got := spline.Tangent(float32(i)); !got.PracticallyEquals(&wantTangent, EPSILON) {
+			t.Errorf("tangent at knot %d failed: got %v, want %v", i, got, wantTangent)
+		}
+	}
+}
+
+func TestLinear(t *testing.T) {
+	// Evenly spaced collinear control points result in a uniform straight line
+	spline := T{Points: []vec2.T{point(0, 0), point(1, 1), point(2, 2), point(3, 3), point(4, 4)}}
+	for s := float32(0); s <= 2; s += 0.25 {
+		want := point(1+s, 1+s)
+		if got := spline.Point(s); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("point at %f failed: got %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestSegmentConversions(t *testing.T) {
+	spline := T{Points: testPoints}
+	for i := 0; i < spline.Segments(); i++ {
+		bez := spline.Bezier(i)
+		herm := spline.Hermit(i)
+		for _, s := range []float32{0, 0.3, 0.5, 1} {
+			want := spline.Point(float32(i) + s)
+			if got := bez.Point(s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("bezier segment %d at %f failed: got %v, want %v", i, s, got, want)
+			}
+			if got := herm.Point(s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("hermit segment %d at %f failed: got %v, want %v", i, s, got, want)
+			}
+		}
+		if i > 0 {
+			// C2 continuity: second differences of the bezier control points match
+			prev := spline.Bezier(i - 1)
+			a := secondDifference(&prev.P1, &prev.P2, &prev.P3)
+			b := secondDifference(&bez.P0, &bez.P1, &bez.P2)
+			if !a.PracticallyEquals(&b, EPSILON) {
+				t.Errorf("second derivative at knot %d is not continuous: %v != %v", i, a, b)
+			}
+		}
+	}
+}
+
+// secondDifference returns a - 2*b + c.
+func secondDifference(a, b, c *vec2.T) vec2.T {
+	result := b.Scaled(-2)
+	return *result.Add(a).Add(c)
+}
+
+func TestTooFewPoints(t *testing.T) {
+	// Like a spline with coincident control points
+	spline := T{Points: testPoints[:3]}
+	want := mean(testPoints[:3])
+	if got := spline.Point(0.5); !got.PracticallyEquals(&want, EPSILON) {
+		t.Errorf("Point of 3 control points failed: got %v, want %v", got, want)
+	}
+	if got := spline.Tangent(0.5); got != vec2.Zero {
+		t.Errorf("Tangent of 3 control points failed: got %v, want zero", got)
+	}
+	var empty T
+	if got := empty.Point(0); got != vec2.Zero {
+		t.Errorf("Point without control points failed: got %v, want zero", got)
+	}
+}
@@ -0,0 +1,105 @@
+// Package bspline3 contains a float32 type T for 3D uniform cubic B-splines.
+// See: https://en.wikipedia.org/wiki/B-spline
+package bspline3
+
+import (
+	"github.com/ungerik/go3d/bezier3"
+	hermit "github.com/ungerik/go3d/hermit3"
+	"github.com/ungerik/go3d/vec3"
+)
+
+// T holds the control points of a uniform cubic B-spline.
+// The spline does not pass through the control points, but is C2 continuous.
+// Segment i is defined by Points[i] to Points[i+3] and is parameterized
+// by the global parameter t from i to i+1.
+// At least 4 control points are needed for a segment.
+type T struct {
+	Points []vec3.T
+}
+
+// Segments returns the number of segments of the spline.
+func (spline *T) Segments() int {
+	return max(len(spline.Points)-3, 0)
+}
+
+// Point returns a point on the spline at the global parameter t (0,Segments()).
+// Splines with less than 4 control points have no segments,
+// then the mean of the control points is returned.
+func (spline *T) Point(t float32) vec3.T {
+	if spline.Segments() == 0 {
+		return mean(spline.Points)
+	}
+	i, local := spline.segmentParam(t)
+	bez := spline.Bezier(i)
+	return bez.Point(local)
+}
+
+// Tangent returns the tangent of the spline at the global parameter t (0,Segments()),
+// which is the first derivative with respect to t.
+// Splines with less than 4 control points have a zero tangent.
+func (spline *T) Tangent(t float32) vec3.T {
+	if spline.Segments() == 0 {
+		return vec3.Zero
+	}
+	i, local := spline.segmentParam(t)
+	herm := spline.Hermit(i)
+	return herm.Tangent(local)
+}
+
+// Bezier returns segment i of the spline as cubic bezier spline.
+func (spline *T) Bezier(i int) bezier3.T {
+	p0 := &spline.Points[i]
+	p1 := &spline.Points[i+1]
+	p2 := &spline.Points[i+2]
+	p3 := &spline.Points[i+3]
+
+	// (p0 + 4*p1 + p2) / 6
+	b0 := p1.Scaled(4)
+	b0.Add(p0).Add(p2).Scale(1.0 / 6.0)
+	// (2*p1 + p2) / 3
+	b1 := vec3.Interpolate(p1, p2, 1.0/3.0)
+	// (p1 + 2*p2) / 3
+	b2 := vec3.Interpolate(p1, p2, 2.0/3.0)
+	// (p1 + 4*p2 + p3) / 6
+	b3 := p2.Scaled(4)
+	b3.Add(p1).Add(p3).Scale(1.0 / 6.0)
+
+	return bezier3.T{P0: b0, P1: b1, P2: b2, P3: b3}
+}
+
+// Hermit returns segment i of the spline as hermit spline.
+func (spline *T) Hermit(i int) hermit.T {
+	bez := spline.Bezier(i)
+	tangentA := vec3.Sub(&bez.P1, &bez.P0)
+	tangentB := vec3.Sub(&bez.P3, &bez.P2)
+	return hermit.T{
+		A: hermit.PointTangent{Point: bez.P0, Tangent: *tangentA.Scale(3)},
+		B: hermit.PointTangent{Point: bez.P3, Tangent: *tangentB.Scale(3)},
+	}
+}
+
+// segmentParam returns the index of the segment that contains the global parameter t
+// and the local parameter (0,1) within that segment.
+func (spline *T) segmentParam(t float32) (i int, local float32) {
+	segments := spline.Segments()
+	if t <= 0 {
+		return 0, 0
+	}
+	if t >= float32(segments) {
+		return segments - 1, 1
+	}
+	i = int(t)
+	return i, t - float32(i)
+}
+
+// mean returns the mean of the points or zero if there are none.
+func mean(points []vec3.T) vec3.T {
+	var sum vec3.T
+	for i := range points {
+		sum.Add(&points[i])
+	}
+	if len(points) > 0 {
+		sum.Scale(1 / float32(len(points)))
+	}
+	return sum
+}
@@ -0,0 +1,97 @@
+package catmullrom3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/vec3"
+)
+
+const EPSILON = 0.0001
+
+func point(x, y float32) vec3.T {
+	var p vec3.T
+	p[0], p[1] = x, y
+	return p
+}
+
+var testPoints = []vec3.T{point(0, 0), point(1, 2), point(3, 2.5), point(4, 0), point(4.2, -3)}
+
+func TestInterpolatesPoints(t *testing.T) {
+	for _, param := range []Parameterization{Uniform, Centripetal, Chordal} {
+		spline := T{Points: testPoints, Parameterization: param}
+		if spline.Segments() != len(testPoints)-1 {
+			t.Errorf("wrong number of segments: %d", spline.Segments())
+		}
+		for i := range testPoints {
+			if got := spline.Point(float32(i)); !got.PracticallyEquals(&testPoints[i], EPSILON) {
+				t.Errorf("spline with parameterization %f does not pass through point %d: got %v, want %v", param, i, got, testPoints[i])
+			}
+		}
+	}
+}
+
+func TestUniformTangents(t *testing.T) {
+	spline := T{Points: testPoints}
+	for i := 1; i < len(testPoints)-1; i++ {
+		want := vec3.Sub(&testPoints[i+1], &testPoints[i-1])
+		want.Scale(0.5)
+		if got := spline.Tangent(float32(i)); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("uniform tangent at point %d failed: got %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestTangentContinuity(t *testing.T) {
+	for _, param := range []Parameterization{Uniform, Centripetal, Chordal} {
+		spline := T{Points: testPoints, Parameterization: param}
+		for i := 1; i < spline.Segments(); i++ {
+			in := spline.Hermit(i - 1).B.Tangent
+			out := spline.Hermit(i).A.Tangent
+			in.Normalize()
+			out.Normalize()
+			if !in.PracticallyEquals(&out, EPSILON) {
+				t.Errorf("tangent direction at point %d with parameterization %f is not continuous: %v != %v", i, param, in, out)
+			}
+		}
+	}
+}
+
+func TestBezier(t *testing.T) {
+	spline := T{Points: testPoints, Parameterization: Centripetal}
+	for i := 0; i < spline.Segments(); i++ {
+		bez := spline.Bezier(i)
+		for _, s := range []float32{0, 0.3, 0.5, 1} {
+			want := spline.Point(float32(i) + s)
+			if got := bez.Point(s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("bezier segment %d at %f failed: got %v, want %v", i, s, got, want)
+			}
+		}
+	}
+}
+
+func TestCentripetalStraightLine(t *testing.T) {
+	// Unevenly spaced points on a line stay on the line without overshooting
+	spline := T{Points: []vec3.T{point(0, 0), point(1, 0), point(10, 0), point(11, 0)}, Parameterization: Centripetal}
+	for s := float32(0); s <= 3; s += 0.125 {
+		p := spline.Point(s)
+		if math.Abs(float64(p[1])) > EPSILON || p[0] < -EPSILON || p[0] > 11+EPSILON {
+			t.Errorf("point at %f is not on the line segment: %v", s, p)
+		}
+	}
+}
+
+func TestDegenerate(t *testing.T) {
+	empty := T{}
+	if empty.Segments() != 0 || empty.Point(0.5) != vec3.Zero {
+		t.Errorf("empty spline failed")
+	}
+	single := T{Points: testPoints[:1]}
+	if got := single.Point(0.5); got != testPoints[0] {
+		t.Errorf("spline with a single point should return that point, got %v", got)
+	}
+	duplicate := T{Points: []vec3.T{point(0, 0), point(0, 0), point(1, 1)}, Parameterization: Chordal}
+	if p := duplicate.Point(1.5); math.IsNaN(float64(p[0])) || math.IsNaN(float64(p[1])) {
+		t.Errorf("coincident points should not result in NaN, got %v", p)
+	}
+}
@@ -0,0 +1,120 @@
+// Package circle contains a float32 type T for bounding circles of 2D points
+// with a fast approximation after Ritter and the exact minimal circle after Welzl.
+// See: https://en.wikipedia.org/wiki/Smallest-circle_problem
+package circle
+
+import (
+	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/mat3"
+	"github.com/ungerik/go3d/vec2"
+)
+
+// T is a circle defined by its Center and Radius.
+type T struct {
+	Center vec2.T
+	Radius float32
+}
+
+// FromRect returns the smallest circle containing rect.
+func FromRect(rect *vec2.Rect) T {
+	diagonal := vec2.Sub(&rect.Max, &rect.Min)
+	return T{Center: vec2.Interpolate(&rect.Min, &rect.Max, 0.5), Radius: diagonal.Length() / 2}
+}
+
+// Rect returns the bounding rectangle of the circle.
+func (circle *T) Rect() vec2.Rect {
+	r := vec2.T{circle.Radius, circle.Radius}
+	return vec2.Rect{Min: vec2.Sub(&circle.Center, &r), Max: vec2.Add(&circle.Center, &r)}
+}
+
+// Area returns the area of the circle.
+func (circle *T) Area() float32 {
+	return math.Pi * circle.Radius * circle.Radius
+}
+
+// ContainsPoint returns if p is inside or on the border of the circle.
+func (circle *T) ContainsPoint(p *vec2.T) bool {
+	return squareDistance(&circle.Center, p) <= circle.Radius*circle.Radius
+}
+
+// ContainsCircle returns if other is completely inside of the circle.
+func (circle *T) ContainsCircle(other *T) bool {
+	if other.Radius > circle.Radius {
+		return false
+	}
+	d := circle.Radius - other.Radius
+	return squareDistance(&circle.Center, &other.Center) <= d*d
+}
+
+// Intersects returns if the circle and other overlap or touch.
+func (circle *T) Intersects(other *T) bool {
+	r := circle.Radius + other.Radius
+	return squareDistance(&circle.Center, &other.Center) <= r*r
+}
+
+// IntersectsRect returns if the circle and rect overlap or touch.
+func (circle *T) IntersectsRect(rect *vec2.Rect) bool {
+	closest := circle.Center.Clamped(&rect.Min, &rect.Max)
+	return circle.ContainsPoint(&closest)
+}
+
+// Join enlarges the circle to the smallest circle that contains also other.
+func (circle *T) Join(other *T) *T {
+	if circle.ContainsCircle(other) {
+		return circle
+	}
+	if other.ContainsCircle(circle) {
+		*circle = *other
+		return circle
+	}
+	distance := math.Sqrt(squareDistance(&circle.Center, &other.Center))
+	radius := (distance + circle.Radius + other.Radius) / 2
+	// Move the center towards other, so that the far sides of both circles touch the result
+	circle.Center = vec2.Interpolate(&circle.Center, &other.Center, (radius-circle.Radius)/distance)
+	circle.Radius = radius
+	return circle
+}
+
+// Joined returns the smallest circle containing both a and b.
+func Joined(a, b *T) T {
+	joined := *a
+	joined.Join(b)
+	return joined
+}
+
+// Transform transforms the circle by the 2D affine matrix mat
+// with the translation in its third column.
+// The radius is scaled by the largest scaling of mat,
+// which is the spectral norm of its upper 2x2 matrix,
+// so the result contains the transformed circle
+// which is an ellipse for non uniform scaling or shearing.
+func (circle *T) Transform(mat *mat3.T) *T {
+	c := circle.Center
+	circle.Center = vec2.T{
+		mat[0][0]*c[0] + mat[1][0]*c[1] + mat[2][0],
+		mat[0][1]*c[0] + mat[1][1]*c[1] + mat[2][1],
+	}
+	// The largest eigenvalue of M^T * M is the square of the largest scaling
+	xAxis, yAxis := vec2.T{mat[0][0], mat[0][1]}, vec2.T{mat[1][0], mat[1][1]}
+	xy := vec2.Dot(&xAxis, &yAxis)
+	mtm := mat3.T{
+		{xAxis.LengthSqr(), xy, 0},
+		{xy, yAxis.LengthSqr(), 0},
+		{0, 0, 0},
+	}
+	values, _ := mtm.EigenSymmetric()
+	circle.Radius *= math.Sqrt(max(values[0], 0))
+	return circle
+}
+
+// Transformed returns a copy of the circle transformed by the 2D affine matrix mat.
+func (circle *T) Transformed(mat *mat3.T) T {
+	result := *circle
+	result.Transform(mat)
+	return result
+}
+
+func squareDistance(a, b *vec2.T) float32 {
+	d := vec2.Sub(a, b)
+	return d.LengthSqr()
+}
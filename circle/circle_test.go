@@ -0,0 +1,138 @@
+package circle
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ungerik/go3d/mat3"
+	"github.com/ungerik/go3d/vec2"
+)
+
+const EPSILON = 0.0001
+
+func abs(x float32) float32 {
+	return float32(math.Abs(float64(x)))
+}
+
+// checkCircle checks that the circle contains all points and has the wanted center and radius.
+func checkCircle(t *testing.T, name string, circle T, points []vec2.T, center vec2.T, radius float32) {
+	t.Helper()
+	for i := range points {
+		if !circle.ContainsPoint(&points[i]) {
+			t.Errorf("%s failed: point %v is not contained in %v", name, points[i], circle)
+		}
+	}
+	d := vec2.Sub(&circle.Center, &center)
+	if d.Length() > EPSILON || abs(circle.Radius-radius) > EPSILON {
+		t.Errorf("%s failed: got %v, want center %v and radius %f", name, circle, center, radius)
+	}
+}
+
+func TestMinimalFromPoints(t *testing.T) {
+	square := []vec2.T{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}}
+	checkCircle(t, "square", MinimalFromPoints(square), square, vec2.Zero, float32(math.Sqrt(2)))
+
+	// Points inside of and on the circle around (1, 2) with radius 5
+	rnd := rand.New(rand.NewSource(1))
+	center := vec2.T{1, 2}
+	points := []vec2.T{{6, 2}, {-4, 2}, {1, 7}, {1, -3}}
+	for i := 0; i < 1000; i++ {
+		angle := rnd.Float64() * 2 * math.Pi
+		r := 5 * rnd.Float64()
+		points = append(points, vec2.T{1 + float32(r*math.Cos(angle)), 2 + float32(r*math.Sin(angle))})
+	}
+	minimal := MinimalFromPoints(points)
+	checkCircle(t, "disk", minimal, points, center, 5)
+	ritter := FromPoints(points)
+	checkCircle(t, "Ritter", ritter, points, ritter.Center, ritter.Radius)
+	if ritter.Radius < minimal.Radius {
+		t.Errorf("Ritter failed: radius %f is smaller than the minimal radius %f", ritter.Radius, minimal.Radius)
+	}
+
+	triangle := []vec2.T{{0, 0}, {4, 0}, {2, 3}}
+	checkCircle(t, "triangle", MinimalFromPoints(triangle), triangle, vec2.T{2, 5 / 6.0}, 13/6.0)
+	obtuse := []vec2.T{{0, 0}, {4, 0}, {2, 1}}
+	checkCircle(t, "obtuse triangle", MinimalFromPoints(obtuse), obtuse, vec2.T{2, 0}, 2)
+}
+
+func TestMinimalFromPointsDegenerated(t *testing.T) {
+	if circle := MinimalFromPoints(nil); circle != (T{}) {
+		t.Errorf("no points failed: got %v", circle)
+	}
+	single := []vec2.T{{1, 2}, {1, 2}}
+	checkCircle(t, "single point", MinimalFromPoints(single), single, vec2.T{1, 2}, 0)
+	collinear := []vec2.T{{0, 0}, {1, 1}, {4, 4}, {2, 2}, {1, 1}}
+	checkCircle(t, "collinear", MinimalFromPoints(collinear), collinear, vec2.T{2, 2}, float32(math.Sqrt(8)))
+	// Cocircular points
+	var polygon []vec2.T
+	for i := 0; i < 16; i++ {
+		angle := float64(i) * math.Pi / 8
+		polygon = append(polygon, vec2.T{float32(math.Cos(angle)), float32(math.Sin(angle))})
+	}
+	checkCircle(t, "cocircular", MinimalFromPoints(polygon), polygon, vec2.Zero, 1)
+}
+
+func TestContains(t *testing.T) {
+	circle := T{Center: vec2.T{1, 0}, Radius: 2}
+	if p := (vec2.T{3, 0}); !circle.ContainsPoint(&p) {
+		t.Errorf("ContainsPoint failed for point on the border")
+	}
+	if p := (vec2.T{2, 2}); circle.ContainsPoint(&p) {
+		t.Errorf("ContainsPoint failed for point outside")
+	}
+	inner := T{Center: vec2.T{2, 0}, Radius: 1}
+	if !circle.ContainsCircle(&inner) || inner.ContainsCircle(&circle) {
+		t.Errorf("ContainsCircle failed")
+	}
+	other := T{Center: vec2.T{5, 0}, Radius: 2}
+	far := T{Center: vec2.T{5, 1}, Radius: 2}
+	if !circle.Intersects(&other) || circle.Intersects(&far) {
+		t.Errorf("Intersects failed")
+	}
+	rect := vec2.Rect{Min: vec2.T{2, 1}, Max: vec2.T{4, 3}}
+	corner := vec2.Rect{Min: vec2.T{2.5, 1.5}, Max: vec2.T{4, 3}}
+	if !circle.IntersectsRect(&rect) || circle.IntersectsRect(&corner) {
+		t.Errorf("IntersectsRect failed")
+	}
+}
+
+func TestJoin(t *testing.T) {
+	a := T{Center: vec2.T{0, 0}, Radius: 1}
+	b := T{Center: vec2.T{4, 0}, Radius: 2}
+	joined := Joined(&a, &b)
+	if joined.Center != (vec2.T{2.5, 0}) || joined.Radius != 3.5 {
+		t.Errorf("Joined failed: got %v", joined)
+	}
+	inner := T{Center: vec2.T{4.5, 0}, Radius: 1}
+	if joined := Joined(&inner, &b); joined != b {
+		t.Errorf("Joined of contained circle failed: got %v", joined)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	// Rotation by 90 degrees with the scaling (2, 3) and the translation (1, 1)
+	mat := mat3.T{
+		{0, 2, 0},
+		{-3, 0, 0},
+		{1, 1, 1},
+	}
+	circle := T{Center: vec2.T{1, 0}, Radius: 1}
+	transformed := circle.Transformed(&mat)
+	want := vec2.T{1, 3}
+	d := vec2.Sub(&transformed.Center, &want)
+	if d.Length() > EPSILON || abs(transformed.Radius-3) > EPSILON {
+		t.Errorf("Transformed failed: got %v, want center %v and radius 3", transformed, want)
+	}
+	// Shear x' = x + y scales by the golden ratio along its major axis
+	shear := mat3.T{
+		{1, 0, 0},
+		{1, 1, 0},
+		{0, 0, 1},
+	}
+	sheared := T{Radius: 1}
+	sheared.Transform(&shear)
+	if want := float32(1+math.Sqrt(5)) / 2; abs(sheared.Radius-want) > EPSILON {
+		t.Errorf("Transform with shear failed: got radius %v, want %v", sheared.Radius, want)
+	}
+}
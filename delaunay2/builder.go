@@ -0,0 +1,204 @@
+package delaunay2
+
+import (
+	"github.com/ungerik/go3d/vec2"
+)
+
+// ghost is the vertex index of the point at infinity.
+// Ghost triangles connect the edges of the convex hull with it,
+// so that points outside of the hull are inserted like points inside of it, see:
+// Jonathan Richard Shewchuk, Lecture Notes on Delaunay Mesh Generation, 2012.
+const ghost = -1
+
+// builderTriangle is a left winding triangle of the Bowyer-Watson algorithm,
+// neighbor i shares the edge from vertex i to vertex i+1.
+type builderTriangle struct {
+	vertices  [3]int
+	neighbors [3]int
+	removed   bool
+}
+
+// ghostCorner returns the corner of the ghost vertex or -1 for triangles without it.
+func (t *builderTriangle) ghostCorner() int {
+	for i, v := range t.vertices {
+		if v == ghost {
+			return i
+		}
+	}
+	return -1
+}
+
+// builder inserts points into a triangulation with ghost triangles.
+type builder struct {
+	points    []vec2.T
+	initial   [3]int
+	triangles []builderTriangle
+	last      int
+
+	// Reused buffers of insert
+	cavity []int
+	border []borderEdge
+}
+
+type borderEdge struct {
+	a, b     int
+	neighbor int
+}
+
+// newBuilder returns a builder with the first triangle of three points that are not collinear
+// or nil if there are no such points.
+func newBuilder(points []vec2.T, order []int) *builder {
+	a, b, c := order[0], -1, -1
+	for _, i := range order {
+		if b == -1 && points[i] != points[a] {
+			b = i
+		} else if b != -1 && orient(&points[a], &points[b], &points[i]) != 0 {
+			c = i
+			break
+		}
+	}
+	if c == -1 {
+		return nil
+	}
+	if orient(&points[a], &points[b], &points[c]) < 0 {
+		b, c = c, b
+	}
+	return &builder{
+		points:  points,
+		initial: [3]int{a, b, c},
+		triangles: []builderTriangle{
+			{vertices: [3]int{a, b, c}, neighbors: [3]int{1, 2, 3}},
+			{vertices: [3]int{b, a, ghost}, neighbors: [3]int{0, 3, 2}},
+			{vertices: [3]int{c, b, ghost}, neighbors: [3]int{0, 1, 3}},
+			{vertices: [3]int{a, c, ghost}, neighbors: [3]int{0, 2, 1}},
+		},
+	}
+}
+
+// hullEdge returns the points of the edge of the ghost triangle that is on the convex hull,
+// the triangulation is right of the edge.
+func (b *builder) hullEdge(t *builderTriangle, corner int) (u, v *vec2.T) {
+	return &b.points[t.vertices[(corner+1)%3]], &b.points[t.vertices[(corner+2)%3]]
+}
+
+// inCircumcircle returns if p is inside of the circumcircle of the triangle.
+// The circumcircle of a ghost triangle is the open half plane left of its hull edge
+// and the open hull edge itself.
+func (b *builder) inCircumcircle(triangle int, p *vec2.T) bool {
+	t := &b.triangles[triangle]
+	corner := t.ghostCorner()
+	if corner == -1 {
+		return inCircle(&b.points[t.vertices[0]], &b.points[t.vertices[1]], &b.points[t.vertices[2]], p)
+	}
+	u, v := b.hullEdge(t, corner)
+	if o := orient(u, v, p); o != 0 {
+		return o > 0
+	}
+	pu, vu := vec2.Sub(p, u), vec2.Sub(v, u)
+	pv := vec2.Sub(p, v)
+	return vec2.Dot(&pu, &vu) > 0 && vec2.Dot(&pv, &vu) < 0
+}
+
+// locate returns the triangle that contains p by walking from the last created triangle.
+// Points outside of the convex hull are located in a ghost triangle.
+func (b *builder) locate(p *vec2.T) int {
+	tri := b.last
+	for steps := 0; steps < len(b.triangles); steps++ {
+		t := &b.triangles[tri]
+		if corner := t.ghostCorner(); corner != -1 {
+			if u, v := b.hullEdge(t, corner); orient(u, v, p) > 0 {
+				return tri
+			}
+			tri = t.neighbors[(corner+1)%3]
+			continue
+		}
+		next := -1
+		for i := 0; i < 3; i++ {
+			if orient(&b.points[t.vertices[i]], &b.points[t.vertices[(i+1)%3]], p) < 0 {
+				next = t.neighbors[i]
+				break
+			}
+		}
+		if next == -1 {
+			return tri
+		}
+		tri = next
+	}
+	// Rounding errors made the walk cycle, fall back to a linear search
+	for i := range b.triangles {
+		if !b.triangles[i].removed && b.inCircumcircle(i, p) {
+			return i
+		}
+	}
+	return b.last
+}
+
+// insert removes all triangles whose circumcircle contains the point
+// and connects the border of the resulting cavity with the point.
+func (b *builder) insert(index int) {
+	if index == b.initial[0] || index == b.initial[1] || index == b.initial[2] {
+		return
+	}
+	p := &b.points[index]
+	start := b.locate(p)
+	for _, v := range b.triangles[start].vertices {
+		if v != ghost && b.points[v] == *p {
+			// Duplicate point
+			return
+		}
+	}
+
+	// Flood fill the cavity from the triangle that contains the point
+	b.cavity = append(b.cavity[:0], start)
+	b.border = b.border[:0]
+	b.triangles[start].removed = true
+	for i := 0; i < len(b.cavity); i++ {
+		t := b.triangles[b.cavity[i]]
+		for j, n := range t.neighbors {
+			if b.triangles[n].removed {
+				continue
+			}
+			if b.inCircumcircle(n, p) {
+				b.triangles[n].removed = true
+				b.cavity = append(b.cavity, n)
+				continue
+			}
+			b.border = append(b.border, borderEdge{a: t.vertices[j], b: t.vertices[(j+1)%3], neighbor: n})
+		}
+	}
+
+	// Connect every border edge a, b with the point,
+	// new triangles that start at b follow those that end at b
+	first := len(b.triangles)
+	startsAt := make(map[int]int, len(b.border))
+	for i, e := range b.border {
+		startsAt[e.a] = first + i
+		b.triangles = append(b.triangles, builderTriangle{
+			vertices:  [3]int{e.a, e.b, index},
+			neighbors: [3]int{e.neighbor, -1, -1},
+		})
+		n := &b.triangles[e.neighbor]
+		for j := 0; j < 3; j++ {
+			if n.vertices[j] == e.b {
+				n.neighbors[j] = first + i
+			}
+		}
+	}
+	for i, e := range b.border {
+		next := startsAt[e.b]
+		b.triangles[first+i].neighbors[1] = next
+		b.triangles[next].neighbors[2] = first + i
+	}
+	b.last = first
+}
+
+// result returns the remaining triangles without the ghost triangles.
+func (b *builder) result() [][3]int {
+	var triangles [][3]int
+	for i := range b.triangles {
+		if t := &b.triangles[i]; !t.removed && t.ghostCorner() == -1 {
+			triangles = append(triangles, t.vertices)
+		}
+	}
+	return triangles
+}
@@ -0,0 +1,135 @@
+package delaunay2
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ungerik/go3d/vec2"
+)
+
+const EPSILON = 0.0001
+
+// randomPoints returns n random points in the square from (0,0) to (10,10)
+// including the corners of the square.
+func randomPoints(n int) []vec2.T {
+	rnd := rand.New(rand.NewSource(1))
+	points := []vec2.T{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	for len(points) < n {
+		points = append(points, vec2.T{rnd.Float32() * 10, rnd.Float32() * 10})
+	}
+	return points
+}
+
+func area(polygon []vec2.T) float32 {
+	var a float32
+	for i := range polygon {
+		a += vec2.Cross(&polygon[i], &polygon[(i+1)%len(polygon)])
+	}
+	return a / 2
+}
+
+func abs(x float32) float32 {
+	return float32(math.Abs(float64(x)))
+}
+
+func TestNew(t *testing.T) {
+	points := randomPoints(200)
+	d := New(points)
+	var sum float32
+	for i, tri := range d.Triangles {
+		a, b, c := &points[tri[0]], &points[tri[1]], &points[tri[2]]
+		if orient(a, b, c) <= 0 {
+			t.Errorf("triangle %v is not left winding", tri)
+		}
+		sum += float32(orient(a, b, c)) / 2
+		for j := range points {
+			if j != tri[0] && j != tri[1] && j != tri[2] && inCircle(a, b, c, &points[j]) {
+				t.Errorf("point %d is inside of the circumcircle of triangle %d", j, i)
+			}
+		}
+		for j, n := range d.Neighbors[i] {
+			if n == -1 {
+				continue
+			}
+			shared := 0
+			for _, v := range d.Triangles[n] {
+				if v == tri[j] || v == tri[(j+1)%3] {
+					shared++
+				}
+			}
+			if shared != 2 {
+				t.Errorf("neighbor %d of triangle %d doesn't share the edge", n, i)
+			}
+		}
+	}
+	// The triangles cover the convex hull, which is the square
+	if abs(sum-100) > EPSILON*100 {
+		t.Errorf("area failed: got %f, want 100", sum)
+	}
+	// Euler's formula for triangulations with h points on the hull: 2n - 2 - h triangles
+	if want := 2*len(points) - 2 - 4; len(d.Triangles) != want {
+		t.Errorf("number of triangles failed: got %d, want %d", len(d.Triangles), want)
+	}
+}
+
+func TestDegenerated(t *testing.T) {
+	// Cocircular points on a grid with duplicates
+	var points []vec2.T
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 5; x++ {
+			points = append(points, vec2.T{float32(x), float32(y)})
+		}
+	}
+	points = append(points, vec2.T{2, 2}, vec2.T{0, 0})
+	d := New(points)
+	if len(d.Triangles) != 32 {
+		t.Errorf("grid failed: got %d triangles, want 32", len(d.Triangles))
+	}
+
+	collinear := New([]vec2.T{{0, 0}, {1, 1}, {2, 2}, {3, 3}})
+	if len(collinear.Triangles) != 0 {
+		t.Errorf("collinear failed: got %v", collinear.Triangles)
+	}
+	if few := New([]vec2.T{{0, 0}, {1, 1}}); len(few.Triangles) != 0 {
+		t.Errorf("two points failed: got %v", few.Triangles)
+	}
+}
+
+func TestCircumcenter(t *testing.T) {
+	d := New([]vec2.T{{0, 0}, {2, 0}, {0, 2}})
+	center := d.Circumcenter(0)
+	if want := (vec2.T{1, 1}); !center.PracticallyEquals(&want, EPSILON) {
+		t.Errorf("Circumcenter failed: got %v, want %v", center, want)
+	}
+}
+
+func TestTriangleAt(t *testing.T) {
+	points := randomPoints(50)
+	d := New(points)
+	rnd := rand.New(rand.NewSource(2))
+	for i := 0; i < 100; i++ {
+		p := vec2.T{rnd.Float32() * 10, rnd.Float32() * 10}
+		tri := d.TriangleAt(&p)
+		if tri == -1 || !d.contains(tri, &p) {
+			t.Errorf("TriangleAt(%v) failed: got %d", p, tri)
+		}
+	}
+	if outside := (vec2.T{11, 5}); d.TriangleAt(&outside) != -1 {
+		t.Errorf("TriangleAt(%v) failed: got %d, want -1", outside, d.TriangleAt(&outside))
+	}
+}
+
+func TestPredicatesExact(t *testing.T) {
+	// Collinear points where the float32 cross product is not zero
+	a := vec2.T{0.1, 0.7}
+	b, c := a.Scaled(2), a.Scaled(4)
+	if o := orient(&a, &b, &c); o != 0 {
+		t.Errorf("orient of collinear points failed: got %g, want 0", o)
+	}
+	// Points on the circle through the corners of a square
+	square := []vec2.T{{0.1, 0.1}, {0.7, 0.1}, {0.7, 0.7}, {0.1, 0.7}}
+	if inCircle(&square[0], &square[1], &square[2], &square[3]) {
+		t.Errorf("inCircle of cocircular points failed: got true, want false")
+	}
+}
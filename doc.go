@@ -31,26 +31,65 @@ package go3d
 
 // Import all sub-packages for build
 import (
+	_ "github.com/ungerik/go3d/float64/arclength"
 	_ "github.com/ungerik/go3d/float64/bezier2"
+	_ "github.com/ungerik/go3d/float64/bezier3"
+	_ "github.com/ungerik/go3d/float64/bspline2"
+	_ "github.com/ungerik/go3d/float64/bspline3"
+	_ "github.com/ungerik/go3d/float64/catmullrom2"
+	_ "github.com/ungerik/go3d/float64/catmullrom3"
+	_ "github.com/ungerik/go3d/float64/circle"
+	_ "github.com/ungerik/go3d/float64/delaunay2"
+	_ "github.com/ungerik/go3d/float64/frame"
 	_ "github.com/ungerik/go3d/float64/generic"
 	_ "github.com/ungerik/go3d/float64/hermit2"
 	_ "github.com/ungerik/go3d/float64/hermit3"
+	_ "github.com/ungerik/go3d/float64/hull3"
+	_ "github.com/ungerik/go3d/float64/kochanek2"
+	_ "github.com/ungerik/go3d/float64/kochanek3"
 	_ "github.com/ungerik/go3d/float64/mat2"
 	_ "github.com/ungerik/go3d/float64/mat3"
 	_ "github.com/ungerik/go3d/float64/mat4"
+	_ "github.com/ungerik/go3d/float64/nurbs"
+	_ "github.com/ungerik/go3d/float64/obb"
+	_ "github.com/ungerik/go3d/float64/path2"
+	_ "github.com/ungerik/go3d/float64/polygon2"
+	_ "github.com/ungerik/go3d/float64/predicates"
 	_ "github.com/ungerik/go3d/float64/qbezier2"
+	_ "github.com/ungerik/go3d/float64/qbezier3"
 	_ "github.com/ungerik/go3d/float64/quaternion"
+	_ "github.com/ungerik/go3d/float64/sphere"
 	_ "github.com/ungerik/go3d/float64/vec2"
 	_ "github.com/ungerik/go3d/float64/vec3"
 	_ "github.com/ungerik/go3d/float64/vec4"
 
+	_ "github.com/ungerik/go3d/arclength"
+	_ "github.com/ungerik/go3d/bezier2"
+	_ "github.com/ungerik/go3d/bezier3"
+	_ "github.com/ungerik/go3d/bspline2"
+	_ "github.com/ungerik/go3d/bspline3"
+	_ "github.com/ungerik/go3d/catmullrom2"
+	_ "github.com/ungerik/go3d/catmullrom3"
+	_ "github.com/ungerik/go3d/circle"
+	_ "github.com/ungerik/go3d/delaunay2"
+	_ "github.com/ungerik/go3d/frame"
 	_ "github.com/ungerik/go3d/generic"
 	_ "github.com/ungerik/go3d/hermit2"
 	_ "github.com/ungerik/go3d/hermit3"
+	_ "github.com/ungerik/go3d/hull3"
+	_ "github.com/ungerik/go3d/kochanek2"
+	_ "github.com/ungerik/go3d/kochanek3"
 	_ "github.com/ungerik/go3d/mat2"
 	_ "github.com/ungerik/go3d/mat3"
 	_ "github.com/ungerik/go3d/mat4"
+	_ "github.com/ungerik/go3d/nurbs"
+	_ "github.com/ungerik/go3d/obb"
+	_ "github.com/ungerik/go3d/path2"
+	_ "github.com/ungerik/go3d/polygon2"
+	_ "github.com/ungerik/go3d/qbezier2"
+	_ "github.com/ungerik/go3d/qbezier3"
 	_ "github.com/ungerik/go3d/quaternion"
+	_ "github.com/ungerik/go3d/sphere"
 	_ "github.com/ungerik/go3d/vec2"
 	_ "github.com/ungerik/go3d/vec3"
 	_ "github.com/ungerik/go3d/vec4"
@@ -0,0 +1,167 @@
+// Package arclength contains float64 functions for the numerical computation
+// of curve lengths and a Table type to parameterize curves by arc length.
+// See: https://en.wikipedia.org/wiki/Gauss%E2%80%93Legendre_quadrature
+package arclength
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultTolerance is the relative tolerance used by curve Length methods.
+var DefaultTolerance float64 = 1e-10
+
+// maxDepth limits the recursion of the adaptive quadrature.
+const maxDepth = 16
+
+// Abscissae and weights of the 5 point Gauss-Legendre rule on [-1, 1].
+var (
+	gaussNodes   = [5]float64{-0.9061798459386640, -0.5384693101056831, 0, 0.5384693101056831, 0.9061798459386640}
+	gaussWeights = [5]float64{0.2369268850561891, 0.4786286704993665, 0.5688888888888889, 0.4786286704993665, 0.2369268850561891}
+)
+
+// machineEpsilon is the difference between 1 and the next representable float value.
+var machineEpsilon = math.Nextafter(1, 2) - 1
+
+// Gauss integrates speed from t0 to t1 with a single 5 point Gauss-Legendre rule,
+// which is exact for polynomials up to degree 9.
+func Gauss(speed func(t float64) float64, t0, t1 float64) float64 {
+	half := (t1 - t0) / 2
+	mid := (t0 + t1) / 2
+	var sum float64
+	for i, x := range gaussNodes {
+		sum += gaussWeights[i] * speed(mid+half*x)
+	}
+	return sum * half
+}
+
+// Integrate integrates speed from t0 to t1 with adaptive Gauss-Legendre quadrature.
+// The interval is subdivided until the estimated error is below
+// the relative tolerance or the maximum subdivision depth is reached.
+// Use it with the length of the derivative of a curve as speed to get the arc length.
+func Integrate(speed func(t float64) float64, t0, t1, tolerance float64) float64 {
+	whole := Gauss(speed, t0, t1)
+	return integrate(speed, t0, t1, whole, tolerance*math.Abs(whole), maxDepth)
+}
+
+func integrate(speed func(t float64) float64, t0, t1, whole, tolerance float64, depth int) float64 {
+	mid := (t0 + t1) / 2
+	left := Gauss(speed, t0, mid)
+	right := Gauss(speed, mid, t1)
+	sum := left + right
+	diff := math.Abs(sum - whole)
+	if depth == 0 || diff <= tolerance || diff <= 4*machineEpsilon*math.Abs(sum) {
+		return sum
+	}
+	return integrate(speed, t0, mid, left, tolerance/2, depth-1) +
+		integrate(speed, mid, t1, right, tolerance/2, depth-1)
+}
+
+// Table maps arc length distances along a curve to curve parameters,
+// which allows sampling a curve at uniform spacing.
+// Use NewTable or the ArcLengthTable methods of the curve types to create it.
+type Table struct {
+	// Params holds ascending curve parameters from 0 to 1.
+	Params []float64
+	// Lengths holds the arc length from parameter 0 to Params[i].
+	Lengths []float64
+
+	speed func(t float64) float64
+}
+
+// NewTable creates a Table for a curve with the parameter range (0,1)
+// from the length of its derivative as speed function.
+// The parameter range is divided into segments intervals of equal width.
+// More segments make Param faster to converge, but the table bigger.
+func NewTable(speed func(t float64) float64, segments int) Table {
+	if segments < 1 {
+		segments = 1
+	}
+	table := Table{
+		Params:  make([]float64, segments+1),
+		Lengths: make([]float64, segments+1),
+		speed:   speed,
+	}
+	for i := 1; i <= segments; i++ {
+		table.Params[i] = float64(i) / float64(segments)
+		segment := Integrate(speed, table.Params[i-1], table.Params[i], DefaultTolerance)
+		table.Lengths[i] = table.Lengths[i-1] + segment
+	}
+	return table
+}
+
+// Length returns the total arc length of the curve.
+func (table *Table) Length() float64 {
+	if len(table.Lengths) == 0 {
+		return 0
+	}
+	return table.Lengths[len(table.Lengths)-1]
+}
+
+// Param returns the curve parameter t at which the arc length
+// from the start of the curve equals distance.
+// distance is clamped to the range from 0 to Length().
+func (table *Table) Param(distance float64) float64 {
+	n := len(table.Lengths)
+	if n == 0 {
+		return 0
+	}
+	if distance <= 0 {
+		return table.Params[0]
+	}
+	if distance >= table.Lengths[n-1] {
+		return table.Params[n-1]
+	}
+
+	i := sort.Search(n, func(i int) bool { return table.Lengths[i] >= distance })
+	if table.Lengths[i] == distance {
+		return table.Params[i]
+	}
+	t0, t1 := table.Params[i-1], table.Params[i]
+	l0, l1 := table.Lengths[i-1], table.Lengths[i]
+	if l1 == l0 {
+		return t0
+	}
+	t := t0 + (t1-t0)*(distance-l0)/(l1-l0)
+	if table.speed == nil {
+		return t
+	}
+
+	// Refine the linear interpolation with Newton's method,
+	// falling back to bisection when a step leaves the segment.
+	lo, hi := t0, t1
+	for iter := 0; iter < 8; iter++ {
+		err := l0 + Integrate(table.speed, t0, t, DefaultTolerance) - distance
+		if err > 0 {
+			hi = t
+		} else {
+			lo = t
+		}
+		if math.Abs(err) <= DefaultTolerance*(l1-l0) {
+			break
+		}
+		next := lo
+		if speed := table.speed(t); speed > 0 {
+			next = t - err/speed
+		}
+		if next <= lo || next >= hi {
+			next = (lo + hi) / 2
+		}
+		t = next
+	}
+	return t
+}
+
+// UniformParams appends count curve parameters to dst
+// that divide the curve into count-1 pieces of equal arc length.
+// The first parameter is 0 and the last one is 1 for count > 1.
+func (table *Table) UniformParams(count int, dst []float64) []float64 {
+	if count == 1 {
+		return append(dst, table.Param(0))
+	}
+	length := table.Length()
+	for i := 0; i < count; i++ {
+		dst = append(dst, table.Param(length*float64(i)/float64(count-1)))
+	}
+	return dst
+}
@@ -5,6 +5,7 @@ package bezier2
 import (
 	"fmt"
 
+	"github.com/ungerik/go3d/float64/arclength"
 	"github.com/ungerik/go3d/float64/vec2"
 )
 
@@ -42,11 +43,34 @@ func (bez *T) Tangent(t float64) vec2.T {
 	return Tangent(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t)
 }
 
-// Length returns the length of a cubic bezier spline from A.Point to t (0,1).
+// Derivative returns the first derivative of a cubic bezier spline at t (0,1).
+// In contrast to Tangent it does not panic for a zero derivative.
+func (bez *T) Derivative(t float64) vec2.T {
+	return Derivative(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t)
+}
+
+// Length returns the length of a cubic bezier spline from P0 to t (0,1).
+// See LengthEps for details.
 func (bez *T) Length(t float64) float64 {
 	return Length(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t)
 }
 
+// LengthEps returns the length of a cubic bezier spline from P0 to t (0,1)
+// computed with adaptive Gauss-Legendre quadrature up to the relative tolerance epsilon.
+func (bez *T) LengthEps(t, epsilon float64) float64 {
+	return LengthEps(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t, epsilon)
+}
+
+// ArcLengthTable returns a table that maps arc length distances on the spline
+// to t values, dividing the range (0,1) into segments intervals.
+// Use it to sample the spline at uniform spacing.
+func (bez *T) ArcLengthTable(segments int) arclength.Table {
+	return arclength.NewTable(func(t float64) float64 {
+		d := Derivative(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t)
+		return d.Length()
+	}, segments)
+}
+
 // Point returns a point on a cubic bezier spline at t (0,1).
 func Point(p0, p1, p2, p3 *vec2.T, t float64) vec2.T {
 	t1 := 1.0 - t
@@ -71,6 +95,19 @@ func Point(p0, p1, p2, p3 *vec2.T, t float64) vec2.T {
 
 // Tangent returns a tangent on a cubic bezier spline at t (0,1).
 func Tangent(p0, p1, p2, p3 *vec2.T, t float64) vec2.T {
+	result := Derivative(p0, p1, p2, p3, t)
+
+	if result[0] == 0 && result[1] == 0 {
+		fmt.Printf("zero tangent!  p0=%v, p1=%v, p2=%v, p3=%v, t=%v\n", p0, p1, p2, p3, t)
+		panic("zero tangent of bezier2")
+	}
+
+	return result
+}
+
+// Derivative returns the first derivative of a cubic bezier spline at t (0,1).
+// In contrast to Tangent it does not panic for a zero derivative.
+func Derivative(p0, p1, p2, p3 *vec2.T, t float64) vec2.T {
 	t1 := 1.0 - t
 
 	f := 3.0 * t1 * t1
@@ -87,35 +124,20 @@ func Tangent(p0, p1, p2, p3 *vec2.T, t float64) vec2.T {
 	p3f.Scale(f)
 	result.Add(&p3f)
 
-	if result[0] == 0 && result[1] == 0 {
-		fmt.Printf("zero tangent!  p0=%v, p1=%v, p2=%v, p3=%v, t=%v\n", p0, p1, p2, p3, t)
-		panic("zero tangent of bezier2")
-	}
-
 	return result
 }
 
 // Length returns the length of a cubic bezier spline from p0 to t (0,1).
+// See LengthEps for details.
 func Length(p0, p1, p2, p3 *vec2.T, t float64) float64 {
-	sqrT := t * t
-	t1 := sqrT * 0.5
-	t2 := sqrT * t * 1.0 / 3.0
-	t3 := sqrT*sqrT + 1.0/4.0
-
-	f := 2*t3 - 3*t2 + t
-	result := p0.Scaled(f)
-
-	f = t3 - 2*t2 + t1
-	tAf := p1.Scaled(f)
-	result.Add(&tAf)
-
-	f = t3 - t2
-	tBf := p3.Scaled(f)
-	result.Add(&tBf)
-
-	f = -2*t3 + 3*t2
-	pBf := p2.Scaled(f)
-	result.Add(&pBf)
+	return LengthEps(p0, p1, p2, p3, t, arclength.DefaultTolerance)
+}
 
-	return result.Length()
+// LengthEps returns the length of a cubic bezier spline from p0 to t (0,1)
+// computed with adaptive Gauss-Legendre quadrature up to the relative tolerance epsilon.
+func LengthEps(p0, p1, p2, p3 *vec2.T, t, epsilon float64) float64 {
+	return arclength.Integrate(func(t float64) float64 {
+		d := Derivative(p0, p1, p2, p3, t)
+		return d.Length()
+	}, 0, t, epsilon)
 }
@@ -0,0 +1,129 @@
+package bezier2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// checkFit checks that the splines form a connected curve from the first to the last point
+// and that all points are within maxError of the curve.
+func checkFit(t *testing.T, points []vec2.T, splines []T, maxError float64) {
+	if len(splines) == 0 {
+		t.Fatalf("no splines fitted to %d points", len(points))
+	}
+	if splines[0].P0 != points[0] || splines[len(splines)-1].P3 != points[len(points)-1] {
+		t.Errorf("fitted curve does not start and end at the end points")
+	}
+	for i := 1; i < len(splines); i++ {
+		if splines[i].P0 != splines[i-1].P3 {
+			t.Errorf("spline %d does not start at the end of the previous spline", i)
+		}
+	}
+	for _, p := range points {
+		best := float64(math.Inf(1))
+		for i := range splines {
+			_, _, d := splines[i].Nearest(&p)
+			best = min(best, d)
+		}
+		if best > maxError+EPSILON {
+			t.Errorf("point %v has distance %f from the fitted curve with maximum error %f", p, best, maxError)
+		}
+	}
+}
+
+// isSmoothJoin returns if the tangent directions at the join of a and b are the same.
+func isSmoothJoin(a, b *T) bool {
+	in := vec2.Sub(&a.P3, &a.P2)
+	out := vec2.Sub(&b.P1, &b.P0)
+	return abs(vec2.Cosine(&in, &out)-1) < EPSILON
+}
+
+func TestFitBezier(t *testing.T) {
+	for _, b := range testCurves {
+		points := make([]vec2.T, 50)
+		for i := range points {
+			points[i] = b.Point(float64(i) / float64(len(points)-1))
+		}
+		splines := Fit(points, 0.001, math.Pi, nil)
+		checkFit(t, points, splines, 0.001)
+		for i := 1; i < len(splines); i++ {
+			if !isSmoothJoin(&splines[i-1], &splines[i]) {
+				t.Errorf("join %d of fitted %v is not smooth", i, b)
+			}
+		}
+	}
+}
+
+func TestFitArc(t *testing.T) {
+	points := make([]vec2.T, 200)
+	for i := range points {
+		angle := 1.5 * math.Pi * float64(i) / float64(len(points)-1)
+		points[i] = vec2.T{float64(2 * math.Cos(angle)), float64(2 * math.Sin(angle))}
+	}
+	for _, maxError := range []float64{0.1, 0.01, 0.001} {
+		splines := Fit(points, maxError, math.Pi/4, nil)
+		checkFit(t, points, splines, maxError)
+		for i := 1; i < len(splines); i++ {
+			if !isSmoothJoin(&splines[i-1], &splines[i]) {
+				t.Errorf("join %d of fitted arc with maximum error %f is not smooth", i, maxError)
+			}
+		}
+	}
+	if coarse, fine := Fit(points, 0.1, math.Pi/4, nil), Fit(points, 0.0001, math.Pi/4, nil); len(fine) <= len(coarse) {
+		t.Errorf("smaller maximum error should create more splines: %d <= %d", len(fine), len(coarse))
+	}
+}
+
+func TestFitCorners(t *testing.T) {
+	// Two sides of a square sampled with a corner at (1,0)
+	var points []vec2.T
+	for i := 0; i <= 10; i++ {
+		points = append(points, vec2.T{float64(i) / 10, 0})
+	}
+	for i := 1; i <= 10; i++ {
+		points = append(points, vec2.T{1, float64(i) / 10})
+	}
+	corner := vec2.T{1, 0}
+
+	splines := Fit(points, 0.001, math.Pi/4, nil)
+	checkFit(t, points, splines, 0.001)
+	if len(splines) != 2 || splines[0].P3 != corner {
+		t.Fatalf("expected two splines joined at the corner %v, got %v", corner, splines)
+	}
+	if isSmoothJoin(&splines[0], &splines[1]) {
+		t.Errorf("join at the corner should not be smooth: %v", splines)
+	}
+
+	// Without corner detection the corner is rounded within the maximum error
+	splines = Fit(points, 0.01, math.Pi, nil)
+	checkFit(t, points, splines, 0.01)
+	for i := 1; i < len(splines); i++ {
+		if !isSmoothJoin(&splines[i-1], &splines[i]) {
+			t.Errorf("join %d without corner detection is not smooth", i)
+		}
+	}
+}
+
+func TestFitDegenerated(t *testing.T) {
+	if splines := Fit(nil, 0.1, math.Pi, nil); len(splines) != 0 {
+		t.Errorf("expected no splines for no points, got %v", splines)
+	}
+	p := vec2.T{1, 2}
+	if splines := Fit([]vec2.T{p, p, p}, 0.1, math.Pi, nil); len(splines) != 0 {
+		t.Errorf("expected no splines for a single distinct point, got %v", splines)
+	}
+	q := vec2.T{4, 6}
+	points := []vec2.T{p, p, q, q}
+	splines := Fit(points, 0.1, math.Pi, nil)
+	if len(splines) != 1 || splines[0].P0 != p || splines[0].P3 != q {
+		t.Fatalf("expected a straight spline from %v to %v, got %v", p, q, splines)
+	}
+	if mid, want := splines[0].Point(0.5), (vec2.T{2.5, 4}); !mid.PracticallyEquals(&want, EPSILON) {
+		t.Errorf("spline between two points is not straight, middle is %v", mid)
+	}
+	if points[1] != p || points[2] != q {
+		t.Errorf("Fit must not modify the points")
+	}
+}
@@ -0,0 +1,258 @@
+package bezier2
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// Intersection describes an intersection of a spline with another spline or a line.
+type Intersection struct {
+	// T is the parameter (0,1) of the intersection on the spline.
+	T float64
+	// U is the parameter of the intersection on the other spline,
+	// or on the line with 0 at its first and 1 at its second point.
+	U float64
+	// Point is the position of the intersection.
+	Point vec2.T
+}
+
+// maxIntersections is the maximum number of intersections of two cubic splines,
+// more are only found if both describe the same curve.
+const maxIntersections = 9
+
+// nearestSamples is the number of intervals that are searched by Nearest.
+const nearestSamples = 16
+
+// Nearest returns the parameter t (0,1) of the point on the spline
+// that is nearest to p, the point itself and its distance to p.
+// The spline is sampled at uniform intervals and the best candidates
+// are refined with Newton's method.
+func (bez *T) Nearest(p *vec2.T) (t float64, point vec2.T, distance float64) {
+	bestDist := math.Inf(1)
+	for i := 0; i <= nearestSamples; i++ {
+		s := bez.refineNearest(p, float64(i)/nearestSamples)
+		q := bez.Point(s)
+		if d := vec2.Sub(&q, p); d.LengthSqr() < bestDist {
+			bestDist = d.LengthSqr()
+			t, point = s, q
+		}
+	}
+	return t, point, math.Sqrt(bestDist)
+}
+
+// refineNearest improves the parameter t of the nearest point to p with Newton's method
+// for the root of the derivative of the squared distance.
+func (bez *T) refineNearest(p *vec2.T, t float64) float64 {
+	q := bez.Point(t)
+	dist := vec2.Sub(&q, p)
+	for iter := 0; iter < 8; iter++ {
+		d1 := Derivative(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t)
+		d2 := secondDerivative(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t)
+		f := vec2.Dot(&dist, &d1)
+		df := vec2.Dot(&d1, &d1) + vec2.Dot(&dist, &d2)
+		if df <= 0 {
+			break
+		}
+		next := max(0, min(1, t-f/df))
+		q = bez.Point(next)
+		nextDist := vec2.Sub(&q, p)
+		if nextDist.LengthSqr() >= dist.LengthSqr() {
+			break
+		}
+		t, dist = next, nextDist
+	}
+	return t
+}
+
+// secondDerivative returns the second derivative of a cubic bezier spline at t (0,1).
+func secondDerivative(p0, p1, p2, p3 *vec2.T, t float64) vec2.T {
+	a := vec2.Sub(p2, p1)
+	b := vec2.Sub(p1, p0)
+	a.Sub(&b).Scale(6.0 * (1.0 - t))
+
+	c := vec2.Sub(p3, p2)
+	d := vec2.Sub(p2, p1)
+	c.Sub(&d).Scale(6.0 * t)
+
+	return *a.Add(&c)
+}
+
+// IntersectLine appends the intersections of the spline with the infinite line
+// through a and b to dst, sorted by their parameter on the spline.
+// The spline is subdivided until its parts deviate at most tolerance
+// from straight lines, which bounds the error of the intersection points.
+// If the spline lies on the line, no intersections are returned.
+func (bez *T) IntersectLine(a, b *vec2.T, tolerance float64, dst []Intersection) []Intersection {
+	dir := vec2.Sub(b, a)
+	lengthSqr := dir.LengthSqr()
+	if lengthSqr == 0 {
+		return dst
+	}
+	// Signed distances of the control points from the line
+	// are the control values of the distance function.
+	normal := vec2.T{-dir[1], dir[0]}
+	normal.Scale(1 / math.Sqrt(lengthSqr))
+	var dist [4]float64
+	for i, p := range [4]*vec2.T{&bez.P0, &bez.P1, &bez.P2, &bez.P3} {
+		d := vec2.Sub(p, a)
+		dist[i] = vec2.Dot(&d, &normal)
+	}
+
+	n := len(dst)
+	var roots [8]float64
+	for _, t := range distanceRoots(&dist, 0, 1, 16*tolerance*tolerance, MaxFlattenDepth, roots[:0]) {
+		point := bez.Point(t)
+		if k := len(dst); k > n && pointDistance(&dst[k-1].Point, &point) <= tolerance {
+			continue
+		}
+		d := vec2.Sub(&point, a)
+		dst = append(dst, Intersection{T: t, U: vec2.Dot(&d, &dir) / lengthSqr, Point: point})
+	}
+	return dst
+}
+
+// distanceRoots appends the roots of the one dimensional cubic bezier function
+// with the control values dist over the parameter range t0 to t1 to dst
+// in ascending order.
+func distanceRoots(dist *[4]float64, t0, t1, limit float64, depth int, dst []float64) []float64 {
+	if (dist[0] > 0 && dist[1] > 0 && dist[2] > 0 && dist[3] > 0) ||
+		(dist[0] < 0 && dist[1] < 0 && dist[2] < 0 && dist[3] < 0) {
+		return dst
+	}
+	u := 3*dist[1] - 2*dist[0] - dist[3]
+	v := 3*dist[2] - dist[0] - 2*dist[3]
+	if depth == 0 || max(u*u, v*v) <= limit {
+		d0, d1 := dist[0], dist[3]
+		if d0 == d1 || (d0 > 0 && d1 > 0) || (d0 < 0 && d1 < 0) {
+			return dst
+		}
+		return append(dst, t0+(t1-t0)*d0/(d0-d1))
+	}
+	// de Casteljau split at 0.5
+	d01 := (dist[0] + dist[1]) / 2
+	d12 := (dist[1] + dist[2]) / 2
+	d23 := (dist[2] + dist[3]) / 2
+	d012 := (d01 + d12) / 2
+	d123 := (d12 + d23) / 2
+	d0123 := (d012 + d123) / 2
+	tm := (t0 + t1) / 2
+	left := [4]float64{dist[0], d01, d012, d0123}
+	right := [4]float64{d0123, d123, d23, dist[3]}
+	dst = distanceRoots(&left, t0, tm, limit, depth-1, dst)
+	return distanceRoots(&right, tm, t1, limit, depth-1, dst)
+}
+
+// Intersect appends the intersections of the spline with other to dst,
+// sorted by their parameter T on the spline.
+// Both splines are subdivided until their parts deviate at most tolerance
+// from straight lines, which bounds the error of the intersection points.
+// Touching splines without crossing may not be detected.
+// If both splines describe the same curve, only some of the intersections are returned.
+func (bez *T) Intersect(other *T, tolerance float64, dst []Intersection) []Intersection {
+	x := intersector{
+		limit:     16 * tolerance * tolerance,
+		tolerance: tolerance,
+		start:     len(dst),
+		dst:       dst,
+	}
+	x.intersect(bez, other, 0, 1, 0, 1, MaxFlattenDepth)
+	found := x.dst[x.start:]
+	sort.Slice(found, func(i, j int) bool { return found[i].T < found[j].T })
+	return x.dst
+}
+
+type intersector struct {
+	limit     float64
+	tolerance float64
+	start     int
+	dst       []Intersection
+}
+
+func (x *intersector) intersect(a, b *T, ta0, ta1, tb0, tb1 float64, depth int) {
+	if len(x.dst)-x.start >= maxIntersections {
+		return
+	}
+	boxA := a.controlBox()
+	boxB := b.controlBox()
+	if boxA.Min[0] > boxB.Max[0] || boxB.Min[0] > boxA.Max[0] ||
+		boxA.Min[1] > boxB.Max[1] || boxB.Min[1] > boxA.Max[1] {
+		return
+	}
+
+	flatA := depth == 0 || a.flatness() <= x.limit
+	flatB := depth == 0 || b.flatness() <= x.limit
+	if flatA && flatB {
+		s, u, ok := segmentIntersection(&a.P0, &a.P3, &b.P0, &b.P3)
+		if !ok {
+			return
+		}
+		point := vec2.Interpolate(&a.P0, &a.P3, s)
+		for i := x.start; i < len(x.dst); i++ {
+			if pointDistance(&x.dst[i].Point, &point) <= x.tolerance {
+				return
+			}
+		}
+		x.dst = append(x.dst, Intersection{
+			T:     ta0 + s*(ta1-ta0),
+			U:     tb0 + u*(tb1-tb0),
+			Point: point,
+		})
+		return
+	}
+
+	tam := (ta0 + ta1) / 2
+	tbm := (tb0 + tb1) / 2
+	switch {
+	case flatA:
+		b0, b1 := b.Split(0.5)
+		x.intersect(a, &b0, ta0, ta1, tb0, tbm, depth-1)
+		x.intersect(a, &b1, ta0, ta1, tbm, tb1, depth-1)
+	case flatB:
+		a0, a1 := a.Split(0.5)
+		x.intersect(&a0, b, ta0, tam, tb0, tb1, depth-1)
+		x.intersect(&a1, b, tam, ta1, tb0, tb1, depth-1)
+	default:
+		a0, a1 := a.Split(0.5)
+		b0, b1 := b.Split(0.5)
+		x.intersect(&a0, &b0, ta0, tam, tb0, tbm, depth-1)
+		x.intersect(&a0, &b1, ta0, tam, tbm, tb1, depth-1)
+		x.intersect(&a1, &b0, tam, ta1, tb0, tbm, depth-1)
+		x.intersect(&a1, &b1, tam, ta1, tbm, tb1, depth-1)
+	}
+}
+
+// controlBox returns the bounding box of the control points,
+// which contains the whole spline.
+func (bez *T) controlBox() vec2.Rect {
+	min01 := vec2.Min(&bez.P0, &bez.P1)
+	min23 := vec2.Min(&bez.P2, &bez.P3)
+	max01 := vec2.Max(&bez.P0, &bez.P1)
+	max23 := vec2.Max(&bez.P2, &bez.P3)
+	return vec2.Rect{Min: vec2.Min(&min01, &min23), Max: vec2.Max(&max01, &max23)}
+}
+
+// segmentIntersection returns the parameters s and u of the intersection
+// of the line segments from a0 to a1 and from b0 to b1.
+// ok is false if the segments do not intersect or are parallel.
+func segmentIntersection(a0, a1, b0, b1 *vec2.T) (s, u float64, ok bool) {
+	da := vec2.Sub(a1, a0)
+	db := vec2.Sub(b1, b0)
+	denom := da[0]*db[1] - da[1]*db[0]
+	if denom == 0 {
+		return 0, 0, false
+	}
+	d := vec2.Sub(b0, a0)
+	s = (d[0]*db[1] - d[1]*db[0]) / denom
+	u = (d[0]*da[1] - d[1]*da[0]) / denom
+	if s < 0 || s > 1 || u < 0 || u > 1 {
+		return 0, 0, false
+	}
+	return s, u, true
+}
+
+func pointDistance(a, b *vec2.T) float64 {
+	d := vec2.Sub(a, b)
+	return d.Length()
+}
@@ -0,0 +1,179 @@
+// Package bezier3 contains a float64 type T and functions for 3D cubic Bezier splines.
+// See: http://en.wikipedia.org/wiki/B%C3%A9zier_curve
+package bezier3
+
+import (
+	"fmt"
+
+	"github.com/ungerik/go3d/float64/arclength"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// T holds the data to define a cubic bezier spline.
+type T struct {
+	P0, P1, P2, P3 vec3.T
+}
+
+// Parse parses T from a string. See also String()
+func Parse(s string) (r T, err error) {
+	_, err = fmt.Sscan(s,
+		&r.P0[0], &r.P0[1], &r.P0[2],
+		&r.P1[0], &r.P1[1], &r.P1[2],
+		&r.P2[0], &r.P2[1], &r.P2[2],
+		&r.P3[0], &r.P3[1], &r.P3[2],
+	)
+	return r, err
+}
+
+// String formats T as string. See also Parse().
+func (bez *T) String() string {
+	return fmt.Sprintf("%s %s %s %s",
+		bez.P0.String(), bez.P1.String(),
+		bez.P2.String(), bez.P3.String(),
+	)
+}
+
+// Point returns a point on a cubic bezier spline at t (0,1).
+func (bez *T) Point(t float64) vec3.T {
+	return Point(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t)
+}
+
+// Tangent returns a tangent on a cubic bezier spline at t (0,1).
+func (bez *T) Tangent(t float64) vec3.T {
+	return Tangent(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t)
+}
+
+// SecondDerivative returns the second derivative of a cubic bezier spline at t (0,1).
+func (bez *T) SecondDerivative(t float64) vec3.T {
+	return SecondDerivative(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t)
+}
+
+// Curvature returns the curvature of a cubic bezier spline at t (0,1),
+// which is the inverse of the radius of the osculating circle.
+func (bez *T) Curvature(t float64) float64 {
+	return Curvature(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t)
+}
+
+// Length returns the length of a cubic bezier spline from P0 to t (0,1).
+// See LengthEps for details.
+func (bez *T) Length(t float64) float64 {
+	return Length(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t)
+}
+
+// LengthEps returns the length of a cubic bezier spline from P0 to t (0,1)
+// computed with adaptive Gauss-Legendre quadrature up to the relative tolerance epsilon.
+func (bez *T) LengthEps(t, epsilon float64) float64 {
+	return LengthEps(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t, epsilon)
+}
+
+// ArcLengthTable returns a table that maps arc length distances on the spline
+// to t values, dividing the range (0,1) into segments intervals.
+// Use it to sample the spline at uniform spacing.
+func (bez *T) ArcLengthTable(segments int) arclength.Table {
+	return arclength.NewTable(func(t float64) float64 {
+		d := Tangent(&bez.P0, &bez.P1, &bez.P2, &bez.P3, t)
+		return d.Length()
+	}, segments)
+}
+
+// Split splits the cubic bezier spline at t (0,1) into two splines
+// using the de Casteljau algorithm.
+// left runs from P0 to Point(t) and right from Point(t) to P3.
+func (bez *T) Split(t float64) (left, right T) {
+	p01 := vec3.Interpolate(&bez.P0, &bez.P1, t)
+	p12 := vec3.Interpolate(&bez.P1, &bez.P2, t)
+	p23 := vec3.Interpolate(&bez.P2, &bez.P3, t)
+	p012 := vec3.Interpolate(&p01, &p12, t)
+	p123 := vec3.Interpolate(&p12, &p23, t)
+	p0123 := vec3.Interpolate(&p012, &p123, t)
+	left = T{bez.P0, p01, p012, p0123}
+	right = T{p0123, p123, p23, bez.P3}
+	return left, right
+}
+
+// Point returns a point on a cubic bezier spline at t (0,1).
+func Point(p0, p1, p2, p3 *vec3.T, t float64) vec3.T {
+	t1 := 1.0 - t
+
+	f := t1 * t1 * t1
+	result := p0.Scaled(f)
+
+	f = 3.0 * t1 * t1 * t
+	p1f := p1.Scaled(f)
+	result.Add(&p1f)
+
+	f = 3.0 * t1 * t * t
+	p2f := p2.Scaled(f)
+	result.Add(&p2f)
+
+	f = t * t * t
+	p3f := p3.Scaled(f)
+	result.Add(&p3f)
+
+	return result
+}
+
+// Tangent returns a tangent on a cubic bezier spline at t (0,1).
+// The tangent is the first derivative, which is zero at cusps
+// and at ends with coincident control points.
+func Tangent(p0, p1, p2, p3 *vec3.T, t float64) vec3.T {
+	t1 := 1.0 - t
+
+	f := 3.0 * t1 * t1
+	p1f := vec3.Sub(p1, p0)
+	result := p1f.Scaled(f)
+
+	f = 6.0 * t1 * t
+	p2f := vec3.Sub(p2, p1)
+	p2f.Scale(f)
+	result.Add(&p2f)
+
+	f = 3.0 * t * t
+	p3f := vec3.Sub(p3, p2)
+	p3f.Scale(f)
+	result.Add(&p3f)
+
+	return result
+}
+
+// Length returns the length of a cubic bezier spline from p0 to t (0,1).
+// See LengthEps for details.
+func Length(p0, p1, p2, p3 *vec3.T, t float64) float64 {
+	return LengthEps(p0, p1, p2, p3, t, arclength.DefaultTolerance)
+}
+
+// LengthEps returns the length of a cubic bezier spline from p0 to t (0,1)
+// computed with adaptive Gauss-Legendre quadrature up to the relative tolerance epsilon.
+func LengthEps(p0, p1, p2, p3 *vec3.T, t, epsilon float64) float64 {
+	return arclength.Integrate(func(t float64) float64 {
+		d := Tangent(p0, p1, p2, p3, t)
+		return d.Length()
+	}, 0, t, epsilon)
+}
+
+// SecondDerivative returns the second derivative of a cubic bezier spline at t (0,1).
+func SecondDerivative(p0, p1, p2, p3 *vec3.T, t float64) vec3.T {
+	a := vec3.Sub(p2, p1)
+	b := vec3.Sub(p1, p0)
+	a.Sub(&b).Scale(6.0 * (1.0 - t))
+
+	c := vec3.Sub(p3, p2)
+	d := vec3.Sub(p2, p1)
+	c.Sub(&d).Scale(6.0 * t)
+
+	return *a.Add(&c)
+}
+
+// Curvature returns the curvature of a cubic bezier spline at t (0,1),
+// which is the inverse of the radius of the osculating circle.
+// Returns 0 where the first derivative is zero.
+func Curvature(p0, p1, p2, p3 *vec3.T, t float64) float64 {
+	d1 := Tangent(p0, p1, p2, p3, t)
+	d2 := SecondDerivative(p0, p1, p2, p3, t)
+	speed := d1.Length()
+	if speed == 0 {
+		return 0
+	}
+	cross := vec3.Cross(&d1, &d2)
+	return cross.Length() / (speed * speed * speed)
+}
@@ -0,0 +1,104 @@
+package bezier3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+const EPSILON = 0.0001
+
+func TestParseAndString(t *testing.T) {
+	original := T{vec3.T{0, 0, 0}, vec3.T{1, 1, 0}, vec3.T{2, 1, 1}, vec3.T{3, 0, 1}}
+	parsed, err := Parse(original.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != original {
+		t.Errorf("Parse(String()) failed, got %v, want %v", parsed, original)
+	}
+}
+
+func TestPoint(t *testing.T) {
+	b := T{vec3.T{0, 0, 0}, vec3.T{1, 1, 0}, vec3.T{2, 1, 1}, vec3.T{3, 0, 1}}
+	if got, want := b.Point(0), (vec3.T{0, 0, 0}); got != want {
+		t.Errorf("cubic bezier point at t=0 failed, got %v, want %v", got, want)
+	}
+	if got, want := b.Point(1), (vec3.T{3, 0, 1}); got != want {
+		t.Errorf("cubic bezier point at t=1 failed, got %v, want %v", got, want)
+	}
+	if got, want := b.Point(0.5), (vec3.T{1.5, 0.75, 0.5}); got != want {
+		t.Errorf("cubic bezier point at t=0.5 failed, got %v, want %v", got, want)
+	}
+}
+
+func TestTangent(t *testing.T) {
+	b := T{vec3.T{0, 0, 0}, vec3.T{1, 1, 0}, vec3.T{2, 1, 1}, vec3.T{3, 0, 1}}
+	if got, want := b.Tangent(0), (vec3.T{3, 3, 0}); got != want {
+		t.Errorf("cubic bezier tangent at t=0 failed, got %v, want %v", got, want)
+	}
+	if got, want := b.Tangent(1), (vec3.T{3, -3, 0}); got != want {
+		t.Errorf("cubic bezier tangent at t=1 failed, got %v, want %v", got, want)
+	}
+	if got, want := b.Tangent(0.5), (vec3.T{3, 0, 1.5}); got != want {
+		t.Errorf("cubic bezier tangent at t=0.5 failed, got %v, want %v", got, want)
+	}
+	// Coincident control points at the start
+	degenerated := T{vec3.T{0, 0, 0}, vec3.T{0, 0, 0}, vec3.T{2, 1, 1}, vec3.T{3, 0, 1}}
+	if got := degenerated.Tangent(0); got != (vec3.T{}) {
+		t.Errorf("cubic bezier tangent with coincident control points failed, got %v, want zero", got)
+	}
+}
+
+func TestSecondDerivative(t *testing.T) {
+	b := T{vec3.T{0, 0, 0}, vec3.T{1, 1, 0}, vec3.T{2, 1, 1}, vec3.T{3, 0, 1}}
+	if got, want := b.SecondDerivative(0), (vec3.T{0, -6, 6}); got != want {
+		t.Errorf("cubic bezier second derivative at t=0 failed, got %v, want %v", got, want)
+	}
+	if got, want := b.SecondDerivative(1), (vec3.T{0, -6, -6}); got != want {
+		t.Errorf("cubic bezier second derivative at t=1 failed, got %v, want %v", got, want)
+	}
+	if got, want := b.SecondDerivative(0.5), (vec3.T{0, -6, 0}); got != want {
+		t.Errorf("cubic bezier second derivative at t=0.5 failed, got %v, want %v", got, want)
+	}
+}
+
+func TestCurvature(t *testing.T) {
+	line := T{vec3.T{0, 0, 0}, vec3.T{1, 1, 1}, vec3.T{2, 2, 2}, vec3.T{3, 3, 3}}
+	if got := line.Curvature(0.3); got != 0 {
+		t.Errorf("curvature of straight cubic bezier should be 0, got %f", got)
+	}
+
+	// First derivative (3,3,0) and second derivative (0,-6,0) at t=0
+	b := T{vec3.T{0, 0, 0}, vec3.T{1, 1, 0}, vec3.T{2, 1, 0}, vec3.T{3, 0, 0}}
+	want := 18 / math.Pow(18, 1.5)
+	if got := b.Curvature(0); math.Abs(got-want) > EPSILON {
+		t.Errorf("cubic bezier curvature at t=0 failed, got %f, want %f", got, want)
+	}
+
+	cusp := T{vec3.T{0, 0, 0}, vec3.T{0, 0, 0}, vec3.T{1, 0, 0}, vec3.T{1, 1, 0}}
+	if got := cusp.Curvature(0); got != 0 {
+		t.Errorf("curvature at zero derivative should be 0, got %f", got)
+	}
+}
+
+func TestLength(t *testing.T) {
+	b := T{vec3.T{0, 0, 0}, vec3.T{1, 1, 0}, vec3.T{2, 1, 1}, vec3.T{3, 0, 1}}
+	// Reference value from numerical integration with high precision
+	const want = 3.6240017021
+	if got := b.Length(1); math.Abs(got-want) > EPSILON {
+		t.Errorf("cubic bezier length failed, got %f, want %f", got, want)
+	}
+	if got := b.LengthEps(1, 1e-3); math.Abs(got-want) > 0.01 {
+		t.Errorf("cubic bezier length with low tolerance failed, got %f, want %f", got, want)
+	}
+
+	table := b.ArcLengthTable(16)
+	for i, param := range table.UniformParams(5, nil) {
+		want := table.Length() * float64(i) / 4
+		if got := b.Length(param); math.Abs(got-want) > EPSILON {
+			t.Errorf("uniform parameter %f has length %f, want %f", param, got, want)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package bezier3
+
+import (
+	"math"
+
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// maxFitIterations is the number of times the parameters of the sampled points
+// are improved with Newton's method before a fitted spline is split.
+const maxFitIterations = 4
+
+// Fit approximates the sampled points with a sequence of cubic bezier splines
+// using Philip J. Schneider's algorithm from Graphics Gems (1990).
+// The splines are appended to dst, each one starting where the previous one ends.
+// The distance of every sampled point from the fitted curve is at most maxError.
+// A point where the direction of the samples turns by more than cornerAngle (radians)
+// is a corner, where the tangents of the adjacent splines are independent.
+// At all other joins the splines have the same tangent direction (G1 continuity).
+// Pass math.Pi as cornerAngle to detect no corners.
+// Consecutive duplicate points are ignored, less than two distinct points result in no splines.
+func Fit(points []vec3.T, maxError, cornerAngle float64, dst []T) []T {
+	points = withoutDuplicates(points)
+	if len(points) < 2 {
+		return dst
+	}
+	f := fitter{
+		maxErrorSqr: maxError * maxError,
+		dst:         dst,
+	}
+	cosCorner := math.Cos(cornerAngle)
+	start := 0
+	for i := 1; i < len(points)-1; i++ {
+		in := vec3.Sub(&points[i], &points[i-1])
+		out := vec3.Sub(&points[i+1], &points[i])
+		if vec3.Dot(&in, &out) < cosCorner*in.Length()*out.Length() {
+			f.fitRun(points[start : i+1])
+			start = i
+		}
+	}
+	f.fitRun(points[start:])
+	return f.dst
+}
+
+// withoutDuplicates returns points without consecutive duplicates,
+// points itself is only copied if it contains duplicates.
+func withoutDuplicates(points []vec3.T) []vec3.T {
+	for i := 1; i < len(points); i++ {
+		if points[i] != points[i-1] {
+			continue
+		}
+		result := append([]vec3.T(nil), points[:i]...)
+		for _, p := range points[i+1:] {
+			if p != result[len(result)-1] {
+				result = append(result, p)
+			}
+		}
+		return result
+	}
+	return points
+}
+
+type fitter struct {
+	maxErrorSqr float64
+	dst         []T
+}
+
+// fitRun fits the points between two corners.
+func (f *fitter) fitRun(points []vec3.T) {
+	tangent0 := vec3.Sub(&points[1], &points[0])
+	tangent1 := vec3.Sub(&points[len(points)-2], &points[len(points)-1])
+	f.fit(points, tangent0.Normalized(), tangent1.Normalized())
+}
+
+// fit fits the points with splines that start in the direction tangent0
+// and end in the opposite direction of tangent1.
+func (f *fitter) fit(points []vec3.T, tangent0, tangent1 vec3.T) {
+	if len(points) == 2 {
+		dist := pointDistance(&points[0], &points[1]) / 3
+		f.dst = append(f.dst, fromTangents(&points[0], &points[1], &tangent0, &tangent1, dist, dist))
+		return
+	}
+
+	params := chordLengthParams(points)
+	bez := generateFit(points, params, &tangent0, &tangent1)
+	errSqr, split := bez.fitError(points, params)
+	if errSqr <= f.maxErrorSqr {
+		f.dst = append(f.dst, bez)
+		return
+	}
+	// Close fits are improved by better parameters before splitting
+	if errSqr <= 16*f.maxErrorSqr {
+		for i := 0; i < maxFitIterations; i++ {
+			bez.reparameterize(points, params)
+			bez = generateFit(points, params, &tangent0, &tangent1)
+			errSqr, split = bez.fitError(points, params)
+			if errSqr <= f.maxErrorSqr {
+				f.dst = append(f.dst, bez)
+				return
+			}
+		}
+	}
+
+	// Split at the point of the largest error with a shared tangent for G1 continuity
+	center := vec3.Sub(&points[split-1], &points[split+1])
+	if center.IsZero() {
+		center = vec3.Sub(&points[split-1], &points[split])
+	}
+	center.Normalize()
+	f.fit(points[:split+1], tangent0, center)
+	f.fit(points[split:], center.Inverted(), tangent1)
+}
+
+// fromTangents returns the spline from p0 to p3 with the inner control points
+// at the distances alpha0 and alpha1 in the directions tangent0 and tangent1.
+func fromTangents(p0, p3, tangent0, tangent1 *vec3.T, alpha0, alpha1 float64) T {
+	p1 := tangent0.Scaled(alpha0)
+	p2 := tangent1.Scaled(alpha1)
+	return T{P0: *p0, P1: *p1.Add(p0), P2: *p2.Add(p3), P3: *p3}
+}
+
+// chordLengthParams returns parameters (0,1) for the points
+// proportional to the length of the polyline up to each point.
+func chordLengthParams(points []vec3.T) []float64 {
+	params := make([]float64, len(points))
+	for i := 1; i < len(points); i++ {
+		params[i] = params[i-1] + pointDistance(&points[i], &points[i-1])
+	}
+	length := params[len(params)-1]
+	for i := range params {
+		params[i] /= length
+	}
+	return params
+}
+
+// generateFit returns the spline through the first and last point with the given
+// tangent directions that fits the points at params best in the least squares sense.
+func generateFit(points []vec3.T, params []float64, tangent0, tangent1 *vec3.T) T {
+	first, last := &points[0], &points[len(points)-1]
+	// Normal equations for the distances alpha0 and alpha1 of the inner control points
+	var c00, c01, c11, x0, x1 float64
+	for i, u := range params {
+		s := 1 - u
+		b0 := s * s * s
+		b1 := 3 * u * s * s
+		b2 := 3 * u * u * s
+		b3 := u * u * u
+		a0 := tangent0.Scaled(b1)
+		a1 := tangent1.Scaled(b2)
+		c00 += vec3.Dot(&a0, &a0)
+		c01 += vec3.Dot(&a0, &a1)
+		c11 += vec3.Dot(&a1, &a1)
+
+		p := first.Scaled(b0 + b1)
+		q := last.Scaled(b2 + b3)
+		tmp := vec3.Sub(&points[i], p.Add(&q))
+		x0 += vec3.Dot(&a0, &tmp)
+		x1 += vec3.Dot(&a1, &tmp)
+	}
+
+	var alpha0, alpha1 float64
+	if det := c00*c11 - c01*c01; det != 0 {
+		alpha0 = (x0*c11 - x1*c01) / det
+		alpha1 = (c00*x1 - c01*x0) / det
+	}
+	// Fall back to a third of the chord length for degenerated solutions,
+	// which would produce loops or cusps
+	segLength := pointDistance(first, last)
+	if epsilon := 1e-6 * segLength; alpha0 < epsilon || alpha1 < epsilon {
+		alpha0 = segLength / 3
+		alpha1 = alpha0
+	}
+	return fromTangents(first, last, tangent0, tangent1, alpha0, alpha1)
+}
+
+// fitError returns the largest squared distance of the points from the spline at params
+// and the index of that point, which is never the first or last one.
+func (bez *T) fitError(points []vec3.T, params []float64) (maxSqr float64, index int) {
+	index = len(points) / 2
+	for i := 1; i < len(points)-1; i++ {
+		p := bez.Point(params[i])
+		if d := vec3.Sub(&p, &points[i]); d.LengthSqr() > maxSqr {
+			maxSqr = d.LengthSqr()
+			index = i
+		}
+	}
+	return maxSqr, index
+}
+
+// reparameterize improves params with one step of Newton's method,
+// so that the spline points at params are closer to the points.
+func (bez *T) reparameterize(points []vec3.T, params []float64) {
+	for i, u := range params {
+		q := bez.Point(u)
+		d := vec3.Sub(&q, &points[i])
+		d1 := Tangent(&bez.P0, &bez.P1, &bez.P2, &bez.P3, u)
+		d2 := SecondDerivative(&bez.P0, &bez.P1, &bez.P2, &bez.P3, u)
+		denom := vec3.Dot(&d1, &d1) + vec3.Dot(&d, &d2)
+		if denom != 0 {
+			params[i] = max(0, min(1, u-vec3.Dot(&d, &d1)/denom))
+		}
+	}
+}
+
+func pointDistance(a, b *vec3.T) float64 {
+	d := vec3.Sub(a, b)
+	return d.Length()
+}
@@ -0,0 +1,54 @@
+package bezier3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+func TestFit(t *testing.T) {
+	// Helix with a corner at the end of the first turn
+	var points []vec3.T
+	for i := 0; i <= 100; i++ {
+		angle := 2 * math.Pi * float64(i) / 100
+		points = append(points, vec3.T{float64(math.Cos(angle)), float64(math.Sin(angle)), float64(angle) / 4})
+	}
+	corner := points[len(points)-1]
+	for i := 1; i <= 10; i++ {
+		points = append(points, vec3.T{1, 0, corner[2] + float64(i)/10})
+	}
+
+	const maxError = 0.001
+	splines := Fit(points, maxError, math.Pi/4, nil)
+	if len(splines) < 2 || splines[0].P0 != points[0] || splines[len(splines)-1].P3 != points[len(points)-1] {
+		t.Fatalf("fitted curve does not start and end at the end points: %v", splines)
+	}
+	var curve []vec3.T
+	for i := range splines {
+		if i > 0 && splines[i].P0 != splines[i-1].P3 {
+			t.Errorf("spline %d does not start at the end of the previous spline", i)
+		}
+		curve = splines[i].Flatten(maxError/100, curve)
+	}
+	for _, p := range points {
+		if d := polylineDistance(curve, &p); d > maxError+EPSILON {
+			t.Errorf("point %v has distance %f from the fitted curve", p, d)
+		}
+	}
+
+	cornerJoins := 0
+	for i := 1; i < len(splines); i++ {
+		in := vec3.Sub(&splines[i-1].P3, &splines[i-1].P2)
+		out := vec3.Sub(&splines[i].P1, &splines[i].P0)
+		if math.Abs(vec3.Cosine(&in, &out)-1) > EPSILON {
+			if splines[i].P0 != corner {
+				t.Errorf("join %d at %v is not smooth", i, splines[i].P0)
+			}
+			cornerJoins++
+		}
+	}
+	if cornerJoins != 1 {
+		t.Errorf("expected one join at the corner, got %d", cornerJoins)
+	}
+}
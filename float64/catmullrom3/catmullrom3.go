@@ -0,0 +1,147 @@
+// Package catmullrom3 contains a float64 type T for 3D Catmull-Rom splines
+// through a sequence of points with uniform, centripetal or chordal parameterization.
+// See: https://en.wikipedia.org/wiki/Centripetal_Catmull%E2%80%93Rom_spline
+package catmullrom3
+
+import (
+	"math"
+
+	"github.com/ungerik/go3d/float64/bezier3"
+	hermit "github.com/ungerik/go3d/float64/hermit3"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// Parameterization is the exponent alpha of the distance between points
+// that is used as knot interval of a Catmull-Rom spline.
+type Parameterization float64
+
+const (
+	// Uniform parameterization uses the same knot interval for all points.
+	Uniform Parameterization = 0
+	// Centripetal parameterization avoids cusps and self intersections within segments.
+	Centripetal Parameterization = 0.5
+	// Chordal parameterization uses the distance between points as knot interval.
+	Chordal Parameterization = 1
+)
+
+// T holds the data to define a Catmull-Rom spline that passes through all Points.
+// Segment i runs from Points[i] to Points[i+1] and is parameterized
+// by the global parameter t from i to i+1.
+// The first and last segments use mirrored end points as outer neighbours.
+type T struct {
+	Points           []vec3.T
+	Parameterization Parameterization
+}
+
+// Segments returns the number of segments of the spline.
+func (spline *T) Segments() int {
+	return max(len(spline.Points)-1, 0)
+}
+
+// Point returns a point on the spline at the global parameter t (0,Segments()).
+func (spline *T) Point(t float64) vec3.T {
+	if len(spline.Points) < 2 {
+		return singlePoint(spline.Points)
+	}
+	i, local := segmentParam(t, spline.Segments())
+	herm := spline.Hermit(i)
+	return herm.Point(local)
+}
+
+// Tangent returns the tangent of the spline at the global parameter t (0,Segments()),
+// which is the first derivative with respect to t.
+func (spline *T) Tangent(t float64) vec3.T {
+	if len(spline.Points) < 2 {
+		return vec3.Zero
+	}
+	i, local := segmentParam(t, spline.Segments())
+	herm := spline.Hermit(i)
+	return herm.Tangent(local)
+}
+
+// Hermit returns segment i of the spline as hermit spline.
+func (spline *T) Hermit(i int) hermit.T {
+	p1 := &spline.Points[i]
+	p2 := &spline.Points[i+1]
+	var p0, p3 vec3.T
+	if i > 0 {
+		p0 = spline.Points[i-1]
+	} else {
+		p0 = mirror(p1, p2)
+	}
+	if i+2 < len(spline.Points) {
+		p3 = spline.Points[i+2]
+	} else {
+		p3 = mirror(p2, p1)
+	}
+
+	alpha := float64(spline.Parameterization)
+	dt0 := knotInterval(&p0, p1, alpha)
+	dt1 := knotInterval(p1, p2, alpha)
+	dt2 := knotInterval(p2, &p3, alpha)
+
+	// Tangents of the non-uniform spline scaled to the segment parameter range (0,1)
+	d01 := vec3.Sub(p1, &p0)
+	d01.Scale(1 / dt0)
+	d02 := vec3.Sub(p2, &p0)
+	d02.Scale(1 / (dt0 + dt1))
+	d12 := vec3.Sub(p2, p1)
+	d12.Scale(1 / dt1)
+	d13 := vec3.Sub(&p3, p1)
+	d13.Scale(1 / (dt1 + dt2))
+	d23 := vec3.Sub(&p3, p2)
+	d23.Scale(1 / dt2)
+
+	tangentA := d01.Sub(&d02).Add(&d12).Scaled(dt1)
+	tangentB := d12.Sub(&d13).Add(&d23).Scaled(dt1)
+	return hermit.T{
+		A: hermit.PointTangent{Point: *p1, Tangent: tangentA},
+		B: hermit.PointTangent{Point: *p2, Tangent: tangentB},
+	}
+}
+
+// Bezier returns segment i of the spline as cubic bezier spline.
+func (spline *T) Bezier(i int) bezier3.T {
+	herm := spline.Hermit(i)
+	return herm.Bezier()
+}
+
+// knotInterval returns the distance between a and b raised to the power of alpha.
+// Coincident points get the interval 1 to avoid a division by zero.
+func knotInterval(a, b *vec3.T, alpha float64) float64 {
+	if alpha == 0 {
+		return 1
+	}
+	d := vec3.Sub(b, a)
+	dt := math.Pow(d.LengthSqr(), alpha/2)
+	if dt == 0 {
+		return 1
+	}
+	return dt
+}
+
+// mirror returns p mirrored at center.
+func mirror(center, p *vec3.T) vec3.T {
+	m := center.Scaled(2)
+	return *m.Sub(p)
+}
+
+// segmentParam returns the index of the segment that contains the global parameter t
+// and the local parameter (0,1) within that segment.
+func segmentParam(t float64, segments int) (i int, local float64) {
+	if t <= 0 {
+		return 0, 0
+	}
+	if t >= float64(segments) {
+		return segments - 1, 1
+	}
+	i = int(t)
+	return i, t - float64(i)
+}
+
+func singlePoint(points []vec3.T) vec3.T {
+	if len(points) == 0 {
+		return vec3.Zero
+	}
+	return points[0]
+}
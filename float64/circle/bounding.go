@@ -0,0 +1,161 @@
+package circle
+
+import (
+	"math"
+
+	"math/rand"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// tolerance is the relative error of the squared radius
+// that is accepted as containment while constructing the minimal circle.
+const tolerance = 1e-12
+
+// FromPoints returns a circle containing all points using Ritter's algorithm,
+// which is fast but usually a few percent larger than the minimal circle.
+// See: Jack Ritter, An Efficient Bounding Sphere, Graphics Gems, 1990
+func FromPoints(points []vec2.T) T {
+	if len(points) == 0 {
+		return T{}
+	}
+	a := farthest(points, &points[0])
+	b := farthest(points, a)
+	circle := circumcircle([]vec2.T{*a, *b})
+	for i := range points {
+		p := &points[i]
+		if distance := math.Sqrt(squareDistance(&circle.Center, p)); distance > circle.Radius {
+			// Grow the circle just enough to contain p and the far side of the old circle
+			radius := (circle.Radius + distance) / 2
+			circle.Center = vec2.Interpolate(&circle.Center, p, (radius-circle.Radius)/distance)
+			circle.Radius = radius
+		}
+	}
+	circle.enclose(points)
+	return circle
+}
+
+// MinimalFromPoints returns the smallest circle containing all points
+// using Welzl's algorithm with the points in random order,
+// which takes expected linear time.
+// See: Emo Welzl, Smallest enclosing disks (balls and ellipsoids), 1991
+func MinimalFromPoints(points []vec2.T) T {
+	if len(points) == 0 {
+		return T{}
+	}
+	shuffled := append([]vec2.T(nil), points...)
+	random := rand.New(rand.NewSource(1))
+	random.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	circle := minimal(shuffled, nil)
+	circle.enclose(points)
+	return circle
+}
+
+// minimal returns the smallest circle containing points
+// with the boundary points on its border.
+func minimal(points, boundary []vec2.T) T {
+	circle := circumcircle(boundary)
+	if len(boundary) == 3 {
+		return circle
+	}
+	for i := range points {
+		if !circle.nearlyContains(&points[i]) {
+			circle = minimal(points[:i], append(boundary[:len(boundary):len(boundary)], points[i]))
+		}
+	}
+	return circle
+}
+
+// nearlyContains returns if p is inside of the circle allowing for rounding errors.
+// A negative radius marks the empty circle that contains no points.
+func (circle *T) nearlyContains(p *vec2.T) bool {
+	if circle.Radius < 0 {
+		return false
+	}
+	r2 := circle.Radius * circle.Radius
+	return squareDistance(&circle.Center, p) <= r2+tolerance*r2
+}
+
+// enclose enlarges the radius of the circle to contain all points
+// despite rounding errors of its construction.
+func (circle *T) enclose(points []vec2.T) {
+	for i := range points {
+		if !circle.ContainsPoint(&points[i]) {
+			circle.Radius = math.Sqrt(squareDistance(&circle.Center, &points[i]))
+			for !circle.ContainsPoint(&points[i]) {
+				circle.Radius = math.Nextafter(circle.Radius, math.Inf(1))
+			}
+		}
+	}
+}
+
+// farthest returns the point with the largest distance from p.
+func farthest(points []vec2.T, p *vec2.T) *vec2.T {
+	result, maxDistance := p, float64(0)
+	for i := range points {
+		if d := squareDistance(p, &points[i]); d > maxDistance {
+			result, maxDistance = &points[i], d
+		}
+	}
+	return result
+}
+
+// circumcircle returns the smallest circle with up to three points on its border,
+// the empty circle with a negative radius for no points.
+// For collinear points the smallest circle through two of them
+// that contains all of them is returned.
+func circumcircle(points []vec2.T) T {
+	switch len(points) {
+	case 0:
+		return T{Radius: -1}
+	case 1:
+		return T{Center: points[0]}
+	case 2:
+		return T{
+			Center: vec2.Interpolate(&points[0], &points[1], 0.5),
+			Radius: math.Sqrt(squareDistance(&points[0], &points[1])) / 2,
+		}
+	}
+	// Calculated relative to the first point to reduce rounding errors
+	a := &points[0]
+	b := vec2.Sub(&points[1], a)
+	c := vec2.Sub(&points[2], a)
+	denominator := 2 * vec2.Cross(&b, &c)
+	if denominator == 0 {
+		return circumcircleOfSubset(points)
+	}
+	bb, cc := b.LengthSqr(), c.LengthSqr()
+	offset := vec2.T{
+		(c[1]*bb - b[1]*cc) / denominator,
+		(b[0]*cc - c[0]*bb) / denominator,
+	}
+	return T{Center: vec2.Add(a, &offset), Radius: offset.Length()}
+}
+
+// circumcircleOfSubset returns the smallest circumcircle of all points but one
+// that contains all points, or the largest one if rounding errors prevent that.
+func circumcircleOfSubset(points []vec2.T) T {
+	result, largest := T{Radius: -1}, T{Radius: -1}
+	for skip := range points {
+		subset := make([]vec2.T, 0, len(points)-1)
+		subset = append(subset, points[:skip]...)
+		subset = append(subset, points[skip+1:]...)
+		circle := circumcircle(subset)
+		if circle.Radius > largest.Radius {
+			largest = circle
+		}
+		containsAll := true
+		for i := range points {
+			containsAll = containsAll && circle.nearlyContains(&points[i])
+		}
+		if containsAll && (result.Radius < 0 || circle.Radius < result.Radius) {
+			result = circle
+		}
+	}
+	if result.Radius < 0 {
+		return largest
+	}
+	return result
+}
@@ -0,0 +1,194 @@
+// Package delaunay2 contains a float64 type T for the Delaunay triangulation
+// of 2D points and the dual Voronoi diagram with cells clipped to a rectangle.
+// See: https://en.wikipedia.org/wiki/Delaunay_triangulation
+package delaunay2
+
+import (
+	"sort"
+
+	"github.com/ungerik/go3d/float64/predicates"
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// T is the Delaunay triangulation of a set of points,
+// no point is inside of the circumcircle of any triangle.
+type T struct {
+	// Points are the triangulated points.
+	Points []vec2.T
+	// Triangles are left winding index triples into Points.
+	Triangles [][3]int
+	// Neighbors are the indices of the triangles adjacent to the triangles,
+	// Neighbors[i][j] shares the edge from Triangles[i][j] to Triangles[i][(j+1)%3]
+	// with triangle i, or is -1 at the border of the triangulation.
+	Neighbors [][3]int
+}
+
+// New returns the Delaunay triangulation of points using the Bowyer-Watson algorithm.
+// The triangles cover the convex hull of the points.
+// Duplicate points are only triangulated once,
+// less than three points or collinear points result in no triangles.
+// Points is referenced by the result and must not be modified.
+func New(points []vec2.T) T {
+	t := T{Points: points}
+	if len(points) < 3 {
+		return t
+	}
+	order := hilbertOrder(points)
+	b := newBuilder(points, order)
+	if b == nil {
+		return t
+	}
+	for _, i := range order {
+		b.insert(i)
+	}
+	t.Triangles = b.result()
+	t.Neighbors = neighbors(t.Triangles)
+	return t
+}
+
+// hilbertOrder returns the indices of points sorted along a Hilbert curve,
+// so that consecutive points are close to each other
+// and locating the triangle of the next point takes few steps.
+func hilbertOrder(points []vec2.T) []int {
+	bounds := vec2.Rect{Min: points[0], Max: points[0]}
+	for i := range points {
+		bounds.Min = vec2.Min(&bounds.Min, &points[i])
+		bounds.Max = vec2.Max(&bounds.Max, &points[i])
+	}
+	size := max(bounds.Max[0]-bounds.Min[0], bounds.Max[1]-bounds.Min[1])
+	if size == 0 {
+		size = 1
+	}
+	const n = 1 << 16
+	keys := make([]uint64, len(points))
+	order := make([]int, len(points))
+	for i := range points {
+		x := uint32((points[i][0] - bounds.Min[0]) / size * (n - 1))
+		y := uint32((points[i][1] - bounds.Min[1]) / size * (n - 1))
+		keys[i] = hilbertIndex(n, x, y)
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return keys[order[i]] < keys[order[j]] })
+	return order
+}
+
+// hilbertIndex returns the distance of the cell x, y along the Hilbert curve
+// that fills a grid of n by n cells, where n is a power of two.
+func hilbertIndex(n, x, y uint32) uint64 {
+	var d uint64
+	for s := n / 2; s > 0; s /= 2 {
+		var rx, ry uint32
+		if x&s != 0 {
+			rx = 1
+		}
+		if y&s != 0 {
+			ry = 1
+		}
+		d += uint64(s) * uint64(s) * uint64((3*rx)^ry)
+		// Rotate the quadrant
+		if ry == 0 {
+			if rx == 1 {
+				x = s - 1 - x
+				y = s - 1 - y
+			}
+			x, y = y, x
+		}
+	}
+	return d
+}
+
+// Circumcenter returns the center of the circumcircle of the triangle,
+// which is a vertex of the Voronoi diagram.
+func (t *T) Circumcenter(triangle int) vec2.T {
+	tri := &t.Triangles[triangle]
+	return circumcenter(&t.Points[tri[0]], &t.Points[tri[1]], &t.Points[tri[2]])
+}
+
+// TriangleAt returns the index of the triangle that contains p
+// or -1 if p is outside of the triangulation.
+func (t *T) TriangleAt(p *vec2.T) int {
+	if len(t.Triangles) == 0 {
+		return -1
+	}
+	// Walk from triangle to triangle towards p,
+	// the number of steps is limited to fall back to a linear search
+	// in case rounding errors make the walk cycle
+	tri := 0
+	for steps := 0; steps < len(t.Triangles); steps++ {
+		next := -1
+		for i := 0; i < 3; i++ {
+			a, b := &t.Points[t.Triangles[tri][i]], &t.Points[t.Triangles[tri][(i+1)%3]]
+			if orient(a, b, p) < 0 {
+				next = t.Neighbors[tri][i]
+				break
+			}
+		}
+		if next == -1 {
+			if t.contains(tri, p) {
+				return tri
+			}
+			break
+		}
+		tri = next
+	}
+	for i := range t.Triangles {
+		if t.contains(i, p) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t *T) contains(triangle int, p *vec2.T) bool {
+	tri := &t.Triangles[triangle]
+	a, b, c := &t.Points[tri[0]], &t.Points[tri[1]], &t.Points[tri[2]]
+	return orient(a, b, p) >= 0 && orient(b, c, p) >= 0 && orient(c, a, p) >= 0
+}
+
+// neighbors returns the adjacent triangles of the triangles.
+func neighbors(triangles [][3]int) [][3]int {
+	edges := make(map[[2]int]int, 3*len(triangles))
+	for t, tri := range triangles {
+		for i := 0; i < 3; i++ {
+			edges[[2]int{tri[i], tri[(i+1)%3]}] = t
+		}
+	}
+	result := make([][3]int, len(triangles))
+	for t, tri := range triangles {
+		for i := 0; i < 3; i++ {
+			n, ok := edges[[2]int{tri[(i+1)%3], tri[i]}]
+			if !ok {
+				n = -1
+			}
+			result[t][i] = n
+		}
+	}
+	return result
+}
+
+// orient returns a positive value if c is left of the line from a to b,
+// a negative value if it is right and zero if it is on the line.
+func orient(a, b, c *vec2.T) float64 {
+	return predicates.Orient2D(a, b, c)
+}
+
+// inCircle returns if d is inside of the circumcircle of the left winding triangle a, b, c.
+func inCircle(a, b, c, d *vec2.T) bool {
+	return predicates.InCircle(a, b, c, d) > 0
+}
+
+// circumcenter returns the center of the circle through a, b and c.
+func circumcenter(a, b, c *vec2.T) vec2.T {
+	bx, by := b[0]-a[0], b[1]-a[1]
+	cx, cy := c[0]-a[0], c[1]-a[1]
+	d := 2 * (bx*cy - by*cx)
+	if d == 0 {
+		return vec2.Interpolate(a, c, 0.5)
+	}
+	bb := bx*bx + by*by
+	cc := cx*cx + cy*cy
+	return vec2.T{
+		a[0] + (cy*bb-by*cc)/d,
+		a[1] + (bx*cc-cx*bb)/d,
+	}
+}
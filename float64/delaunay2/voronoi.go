@@ -0,0 +1,114 @@
+package delaunay2
+
+import (
+	"sort"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// Voronoi returns the cells of the Voronoi diagram of the points clipped to bounds.
+// Cell i is the left winding polygon of all positions in bounds
+// that are closer to Points[i] than to any other point.
+// The vertices of the cells inside of bounds are the circumcenters of the triangles.
+// Duplicate points that were not triangulated and points whose cell
+// is outside of bounds have no cell.
+func (t *T) Voronoi(bounds *vec2.Rect) [][]vec2.T {
+	cells := make([][]vec2.T, len(t.Points))
+	var buffer []vec2.T
+	neighbors, included := t.pointNeighbors()
+	for i := range t.Points {
+		if !included[i] {
+			continue
+		}
+		cell := []vec2.T{
+			bounds.Min,
+			{bounds.Max[0], bounds.Min[1]},
+			bounds.Max,
+			{bounds.Min[0], bounds.Max[1]},
+		}
+		p := &t.Points[i]
+		for _, n := range neighbors[i] {
+			// Keep the half plane of positions closer to p than to the neighbor
+			normal := vec2.Sub(&t.Points[n], p)
+			mid := vec2.Interpolate(p, &t.Points[n], 0.5)
+			buffer = clipHalfPlane(cell, &mid, &normal, buffer[:0])
+			cell, buffer = buffer, cell
+			if len(cell) == 0 {
+				break
+			}
+		}
+		if len(cell) >= 3 {
+			cells[i] = append([]vec2.T(nil), cell...)
+		}
+	}
+	return cells
+}
+
+// pointNeighbors returns the indices of the points
+// that are connected to each point by an edge
+// and if the points are part of the triangulation.
+// Collinear points are connected to their neighbors on the line.
+func (t *T) pointNeighbors() (neighbors [][]int, included []bool) {
+	neighbors = make([][]int, len(t.Points))
+	included = make([]bool, len(t.Points))
+	connect := func(a, b int) {
+		for _, n := range neighbors[a] {
+			if n == b {
+				return
+			}
+		}
+		neighbors[a] = append(neighbors[a], b)
+		neighbors[b] = append(neighbors[b], a)
+	}
+	if len(t.Triangles) > 0 {
+		for _, tri := range t.Triangles {
+			for i := 0; i < 3; i++ {
+				connect(tri[i], tri[(i+1)%3])
+				included[tri[i]] = true
+			}
+		}
+		return neighbors, included
+	}
+
+	// Collinear points sorted by their coordinates are sorted along their line
+	order := make([]int, len(t.Points))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := &t.Points[order[i]], &t.Points[order[j]]
+		return a[0] < b[0] || a[0] == b[0] && a[1] < b[1]
+	})
+	prev := -1
+	for _, i := range order {
+		if prev != -1 && t.Points[i] == t.Points[prev] {
+			continue
+		}
+		if prev != -1 {
+			connect(prev, i)
+		}
+		included[i] = true
+		prev = i
+	}
+	return neighbors, included
+}
+
+// clipHalfPlane appends the points of the convex polygon that are clipped
+// to the half plane of positions p with dot(p - point, normal) <= 0 to dst.
+func clipHalfPlane(polygon []vec2.T, point, normal *vec2.T, dst []vec2.T) []vec2.T {
+	side := func(p *vec2.T) float64 {
+		d := vec2.Sub(p, point)
+		return vec2.Dot(&d, normal)
+	}
+	for i := range polygon {
+		a, b := &polygon[i], &polygon[(i+1)%len(polygon)]
+		sa, sb := side(a), side(b)
+		if sa <= 0 {
+			dst = append(dst, *a)
+		}
+		if sa < 0 && sb > 0 || sa > 0 && sb < 0 {
+			dst = append(dst, vec2.Interpolate(a, b, sa/(sa-sb)))
+		}
+	}
+	return dst
+}
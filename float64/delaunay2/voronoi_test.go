@@ -0,0 +1,76 @@
+package delaunay2
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+func TestVoronoi(t *testing.T) {
+	points := randomPoints(100)
+	d := New(points)
+	bounds := vec2.Rect{Min: vec2.T{-1, -1}, Max: vec2.T{11, 11}}
+	cells := d.Voronoi(&bounds)
+
+	var sum float64
+	for i, cell := range cells {
+		if len(cell) < 3 {
+			t.Fatalf("cell %d failed: got %v", i, cell)
+		}
+		a := area(cell)
+		if a <= 0 {
+			t.Errorf("cell %d is not left winding", i)
+		}
+		sum += a
+	}
+	if abs(sum-144) > EPSILON*144 {
+		t.Errorf("area failed: got %f, want 144", sum)
+	}
+
+	// Every position is in the cell of the nearest point
+	rnd := rand.New(rand.NewSource(3))
+	for i := 0; i < 100; i++ {
+		p := vec2.T{rnd.Float64()*12 - 1, rnd.Float64()*12 - 1}
+		nearest := 0
+		for j := range points {
+			dj, dn := vec2.Sub(&p, &points[j]), vec2.Sub(&p, &points[nearest])
+			if dj.LengthSqr() < dn.LengthSqr() {
+				nearest = j
+			}
+		}
+		if !contains(cells[nearest], &p) {
+			t.Errorf("cell %d doesn't contain %v", nearest, p)
+		}
+	}
+}
+
+func TestVoronoiDegenerated(t *testing.T) {
+	bounds := vec2.Rect{Min: vec2.T{0, 0}, Max: vec2.T{4, 4}}
+
+	d := New([]vec2.T{{1, 2}, {3, 2}, {1, 2}})
+	cells := d.Voronoi(&bounds)
+	if cells[2] != nil {
+		t.Errorf("duplicate failed: got %v", cells[2])
+	}
+	for i, want := range []float64{8, 8} {
+		if a := area(cells[i]); abs(a-want) > EPSILON {
+			t.Errorf("cell %d failed: got area %f, want %f", i, a, want)
+		}
+	}
+
+	single := New([]vec2.T{{1, 1}})
+	if cells := single.Voronoi(&bounds); abs(area(cells[0])-16) > EPSILON {
+		t.Errorf("single point failed: got %v", cells[0])
+	}
+}
+
+// contains returns if p is inside of the left winding convex polygon.
+func contains(polygon []vec2.T, p *vec2.T) bool {
+	for i := range polygon {
+		if orient(&polygon[i], &polygon[(i+1)%len(polygon)], p) < -EPSILON {
+			return false
+		}
+	}
+	return true
+}
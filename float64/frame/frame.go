@@ -0,0 +1,145 @@
+// Package frame contains float64 types and functions for moving frames along 3D curves,
+// which are needed to sweep profiles along paths, for example to extrude tubes or roads.
+// Frenet-Serret frames follow the curvature of the curve,
+// rotation minimizing frames have no twist around the tangent.
+// See: https://en.wikipedia.org/wiki/Frenet%E2%80%93Serret_formulas
+package frame
+
+import (
+	"math"
+
+	"github.com/ungerik/go3d/float64/mat3"
+	"github.com/ungerik/go3d/float64/quaternion"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// Curve is a parametric 3D curve with its first derivative.
+// Pointers to hermit3.T, bezier3.T, qbezier3.T and nurbs.Curve implement it.
+// Tangent returns the zero vector where the first derivative is zero, for example at cusps.
+type Curve interface {
+	Point(t float64) vec3.T
+	Tangent(t float64) vec3.T
+}
+
+// SecondDerivativeCurve is a Curve that also provides its second derivative,
+// which is needed for Frenet-Serret frames.
+type SecondDerivativeCurve interface {
+	Curve
+	SecondDerivative(t float64) vec3.T
+}
+
+// Frame is an orthonormal coordinate system at a point of a curve.
+// Tangent has the direction of the curve,
+// Normal and Binormal are perpendicular to it and to each other
+// with Binormal = Tangent x Normal.
+type Frame struct {
+	Point    vec3.T
+	Tangent  vec3.T
+	Normal   vec3.T
+	Binormal vec3.T
+}
+
+// Mat3 returns the rotation matrix with Tangent, Normal and Binormal as columns,
+// which rotates the X axis to Tangent, Y to Normal and Z to Binormal.
+func (frame *Frame) Mat3() mat3.T {
+	return mat3.T{frame.Tangent, frame.Normal, frame.Binormal}
+}
+
+// Quaternion returns the rotation of Mat3() as quaternion.
+func (frame *Frame) Quaternion() quaternion.T {
+	m := frame.Mat3()
+	return m.Quaternion()
+}
+
+// RotationMinimizing appends the rotation minimizing frames of the curve
+// at the ascending parameters params to dst.
+// The frames are computed with the double reflection method of Wang et al. (2008),
+// which rotates every frame to the next one without twisting around the tangent.
+// normal is the initial normal at params[0], it is projected onto the plane
+// perpendicular to the tangent. If normal is nil or parallel to the tangent,
+// an arbitrary perpendicular vector is used.
+// Points where the tangent is zero keep the tangent of the previous point.
+func RotationMinimizing(curve Curve, params []float64, normal *vec3.T, dst []Frame) []Frame {
+	if len(params) == 0 {
+		return dst
+	}
+	prev := Frame{Point: curve.Point(params[0])}
+	prev.Tangent = curve.Tangent(params[0])
+	if prev.Tangent.IsZero() && len(params) > 1 {
+		next := curve.Point(params[1])
+		prev.Tangent = vec3.Sub(&next, &prev.Point)
+	}
+	prev.Tangent = unitOrX(&prev.Tangent)
+
+	if normal != nil {
+		prev.Normal = *normal
+		d := vec3.Dot(&prev.Normal, &prev.Tangent)
+		projected := prev.Tangent.Scaled(d)
+		prev.Normal.Sub(&projected)
+	}
+	if prev.Normal.IsZero() {
+		prev.Normal = perpendicular(&prev.Tangent)
+	}
+	prev.Normal.Normalize()
+	prev.Binormal = vec3.Cross(&prev.Tangent, &prev.Normal)
+	dst = append(dst, prev)
+
+	for _, t := range params[1:] {
+		next := Frame{Point: curve.Point(t), Tangent: curve.Tangent(t)}
+		if next.Tangent.IsZero() {
+			next.Tangent = prev.Tangent
+		} else {
+			next.Tangent.Normalize()
+		}
+		// Reflect the frame at the bisecting plane of the two points,
+		// then at the plane that maps the reflected tangent to the next tangent
+		v1 := vec3.Sub(&next.Point, &prev.Point)
+		normalL := reflect(&prev.Normal, &v1)
+		tangentL := reflect(&prev.Tangent, &v1)
+		v2 := vec3.Sub(&next.Tangent, &tangentL)
+		next.Normal = reflect(&normalL, &v2)
+		// Remove numerical drift
+		d := vec3.Dot(&next.Normal, &next.Tangent)
+		drift := next.Tangent.Scaled(d)
+		next.Normal.Sub(&drift).Normalize()
+		next.Binormal = vec3.Cross(&next.Tangent, &next.Normal)
+		dst = append(dst, next)
+		prev = next
+	}
+	return dst
+}
+
+// reflect returns v reflected at the plane through the origin with the normal n.
+// v is returned unchanged if n is zero.
+func reflect(v, n *vec3.T) vec3.T {
+	nn := vec3.Dot(n, n)
+	if nn == 0 {
+		return *v
+	}
+	r := n.Scaled(-2 * vec3.Dot(n, v) / nn)
+	return *r.Add(v)
+}
+
+// perpendicular returns a unit vector perpendicular to the unit vector v.
+func perpendicular(v *vec3.T) vec3.T {
+	// Cross with the axis of the smallest component for the best precision
+	i := 0
+	if math.Abs(v[1]) < math.Abs(v[i]) {
+		i = 1
+	}
+	if math.Abs(v[2]) < math.Abs(v[i]) {
+		i = 2
+	}
+	var axis vec3.T
+	axis[i] = 1
+	p := vec3.Cross(v, &axis)
+	return *p.Normalize()
+}
+
+// unitOrX returns v normalized, or the X axis if v is zero.
+func unitOrX(v *vec3.T) vec3.T {
+	if v.IsZero() {
+		return vec3.UnitX
+	}
+	return v.Normalized()
+}
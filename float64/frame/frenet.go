@@ -0,0 +1,58 @@
+package frame
+
+import (
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// derivativeStep is the parameter step of the central differences
+// that approximate the third derivative for the torsion.
+const derivativeStep = 1.0 / 1024
+
+// Frenet is the Frenet-Serret frame at a point of a curve
+// with the curvature and torsion of the curve at that point.
+// Normal points to the center of the osculating circle.
+type Frenet struct {
+	Frame
+	// Curvature is the inverse of the radius of the osculating circle.
+	Curvature float64
+	// Torsion is the rate of rotation of the osculating plane around the tangent.
+	Torsion float64
+}
+
+// FrenetFrame returns the Frenet-Serret frame of the curve at t.
+// The torsion is computed with a third derivative that is approximated
+// by central differences of the second derivatives around t,
+// so it is less accurate at the ends of curves that clamp t to their domain like nurbs.Curve.
+// Where the curve is straight the normal is not defined,
+// then an arbitrary perpendicular vector is used and curvature and torsion are zero.
+// Where the first derivative is zero, the direction of the second derivative is used as tangent.
+func FrenetFrame(curve SecondDerivativeCurve, t float64) Frenet {
+	d1 := curve.Tangent(t)
+	d2 := curve.SecondDerivative(t)
+	frenet := Frenet{Frame: Frame{Point: curve.Point(t)}}
+	speed := d1.Length()
+	if speed == 0 {
+		frenet.Tangent = unitOrX(&d2)
+	} else {
+		frenet.Tangent = d1.Scaled(1 / speed)
+	}
+
+	c := vec3.Cross(&d1, &d2)
+	cl := c.Length()
+	if speed == 0 || cl <= 1e-6*speed*d2.Length() {
+		frenet.Normal = perpendicular(&frenet.Tangent)
+		frenet.Binormal = vec3.Cross(&frenet.Tangent, &frenet.Normal)
+		return frenet
+	}
+	frenet.Binormal = c.Scaled(1 / cl)
+	frenet.Normal = vec3.Cross(&frenet.Binormal, &frenet.Tangent)
+	frenet.Curvature = cl / (speed * speed * speed)
+
+	// Torsion = (d1 x d2) * d3 / |d1 x d2|^2
+	before := curve.SecondDerivative(t - derivativeStep)
+	after := curve.SecondDerivative(t + derivativeStep)
+	d3 := vec3.Sub(&after, &before)
+	d3.Scale(1 / (2 * derivativeStep))
+	frenet.Torsion = vec3.Dot(&c, &d3) / (cl * cl)
+	return frenet
+}
@@ -220,3 +220,149 @@ func TestLengthFunctionMatchesMethod(t *testing.T) {
 		}
 	}
 }
+
+func TestTangentIsDerivative(t *testing.T) {
+	herm := T{
+		A: PointTangent{Point: vec2.T{0, 0}, Tangent: vec2.T{1, 0.5}},
+		B: PointTangent{Point: vec2.T{2, 1}, Tangent: vec2.T{0.5, 1}},
+	}
+	if got := herm.Tangent(1); math.Abs(got[0]-0.5) > EPSILON || math.Abs(got[1]-1) > EPSILON {
+		t.Errorf("Tangent(1) should equal B.Tangent: got %v, want %v", got, herm.B.Tangent)
+	}
+	// Compare the method and the function with central differences of Point
+	const h = 0.001
+	for _, tVal := range []float64{0.1, 0.25, 0.5, 0.75, 0.9} {
+		p0 := herm.Point(tVal - h)
+		p1 := herm.Point(tVal + h)
+		function := Tangent(&herm.A.Point, &herm.A.Tangent, &herm.B.Point, &herm.B.Tangent, tVal)
+		for _, tangent := range []vec2.T{herm.Tangent(tVal), function} {
+			for i := range tangent {
+				if diff := (p1[i] - p0[i]) / (2 * h); math.Abs(tangent[i]-diff) > 0.01 {
+					t.Errorf("Tangent(%f) is not the derivative: got %v, differences %f", tVal, tangent, diff)
+				}
+			}
+		}
+	}
+}
+
+func TestLengthAccuracy(t *testing.T) {
+	line := T{
+		A: PointTangent{Point: vec2.T{0, 0}, Tangent: vec2.T{2, 0}},
+		B: PointTangent{Point: vec2.T{2, 0}, Tangent: vec2.T{2, 0}},
+	}
+	if got := line.Length(1); math.Abs(got-2) > EPSILON {
+		t.Errorf("length of straight hermit spline failed: got %f, want 2", got)
+	}
+	if got := line.Length(0.5); math.Abs(got-1) > EPSILON {
+		t.Errorf("length of half straight hermit spline failed: got %f, want 1", got)
+	}
+	if got := line.LengthEps(0.25, 1e-3); math.Abs(got-0.5) > 0.001 {
+		t.Errorf("length of quarter straight hermit spline failed: got %f, want 0.5", got)
+	}
+}
+
+func TestArcLengthTable(t *testing.T) {
+	herm := T{
+		A: PointTangent{Point: vec2.T{0, 0}, Tangent: vec2.T{1, 0.5}},
+		B: PointTangent{Point: vec2.T{2, 1}, Tangent: vec2.T{0.5, 1}},
+	}
+	table := herm.ArcLengthTable(16)
+	if got, want := table.Length(), herm.Length(1); math.Abs(got-want) > EPSILON {
+		t.Errorf("table length failed: got %f, want %f", got, want)
+	}
+	params := table.UniformParams(4, nil)
+	for i, param := range params {
+		want := table.Length() * float64(i) / 3
+		if got := herm.Length(param); math.Abs(got-want) > EPSILON {
+			t.Errorf("uniform parameter %f has length %f, want %f", param, got, want)
+		}
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	herm := T{
+		A: PointTangent{Point: vec2.T{0, 0}, Tangent: vec2.T{3, 4}},
+		B: PointTangent{Point: vec2.T{2, 1}, Tangent: vec2.T{-2, 3}},
+	}
+	for _, tolerance := range []float64{0.1, 0.01, 0.001} {
+		points := herm.Flatten(tolerance, nil)
+		if points[0] != herm.A.Point || points[len(points)-1] != herm.B.Point {
+			t.Errorf("polyline does not start and end at the end points: %v", points)
+		}
+		for s := float64(0); s <= 1; s += 1.0 / 256 {
+			p := herm.Point(s)
+			if d := polylineDistance(points, &p); d > tolerance+0.0001 {
+				t.Errorf("point %v has distance %f from the polyline with tolerance %f", p, d, tolerance)
+			}
+		}
+	}
+}
+
+// polylineDistance returns the distance of p from the nearest segment of the polyline.
+func polylineDistance(points []vec2.T, p *vec2.T) float64 {
+	nearest := float64(-1)
+	for i := 1; i < len(points); i++ {
+		ab := vec2.Sub(&points[i], &points[i-1])
+		ap := vec2.Sub(p, &points[i-1])
+		s := float64(0)
+		if l := ab.LengthSqr(); l > 0 {
+			s = max(0, min(1, vec2.Dot(&ap, &ab)/l))
+		}
+		q := vec2.Interpolate(&points[i-1], &points[i], s)
+		if d := q.Sub(p).Length(); nearest < 0 || d < nearest {
+			nearest = d
+		}
+	}
+	return nearest
+}
+
+func TestNearestAndIntersect(t *testing.T) {
+	// Same curve as the cubic bezier spline (0,0), (1,1), (2,1), (3,0)
+	herm := T{
+		A: PointTangent{Point: vec2.T{0, 0}, Tangent: vec2.T{3, 3}},
+		B: PointTangent{Point: vec2.T{3, 0}, Tangent: vec2.T{3, -3}},
+	}
+	p := vec2.T{1.5, 2}
+	param, _, distance := herm.Nearest(&p)
+	if math.Abs(param-0.5) > 0.0001 || math.Abs(distance-1.25) > 0.0001 {
+		t.Errorf("nearest point failed, got t=%f, distance %f", param, distance)
+	}
+
+	a, b := vec2.T{0, 0.5}, vec2.T{3, 0.5}
+	hits := herm.IntersectLine(&a, &b, 0.0001, nil)
+	if len(hits) != 2 {
+		t.Fatalf("expected 2 line intersections, got %v", hits)
+	}
+	for _, hit := range hits {
+		if point := herm.Point(hit.T); math.Abs(point[1]-0.5) > 0.001 {
+			t.Errorf("line intersection at t=%f is not on the line: %v", hit.T, point)
+		}
+	}
+
+	other := T{
+		A: PointTangent{Point: vec2.T{1.5, -1}, Tangent: vec2.T{0, 1}},
+		B: PointTangent{Point: vec2.T{1.5, 2}, Tangent: vec2.T{0, 1}},
+	}
+	hits = herm.Intersect(&other, 0.0001, nil)
+	if len(hits) != 1 || math.Abs(hits[0].T-0.5) > 0.001 {
+		t.Errorf("curve intersection failed, got %v", hits)
+	}
+}
+
+func TestBezierConversion(t *testing.T) {
+	herm := T{
+		A: PointTangent{Point: vec2.T{0, 0}, Tangent: vec2.T{3, 3}},
+		B: PointTangent{Point: vec2.T{2, 0}, Tangent: vec2.T{3, -3}},
+	}
+	bez := herm.Bezier()
+	for _, s := range []float64{0, 0.3, 0.5, 0.8, 1} {
+		if got, want := bez.Point(s), herm.Point(s); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("bezier point at %f failed: got %v, want %v", s, got, want)
+		}
+	}
+	back := FromBezier(&bez)
+	if !back.A.Point.PracticallyEquals(&herm.A.Point, EPSILON) || !back.A.Tangent.PracticallyEquals(&herm.A.Tangent, EPSILON) ||
+		!back.B.Point.PracticallyEquals(&herm.B.Point, EPSILON) || !back.B.Tangent.PracticallyEquals(&herm.B.Tangent, EPSILON) {
+		t.Errorf("conversion to bezier and back failed: got %v, want %v", back, herm)
+	}
+}
@@ -0,0 +1,250 @@
+package hull3
+
+import (
+	"github.com/ungerik/go3d/float64/polygon2"
+	"github.com/ungerik/go3d/float64/vec2"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// face is a left winding triangle of the hull under construction
+// with the points that are outside of its plane.
+type face struct {
+	vertices [3]int
+	plane    Plane
+	outside  []int
+	removed  bool
+}
+
+type builder struct {
+	points  []vec3.T
+	epsilon float64
+	faces   []face
+	// Face of every directed edge from a vertex to the next vertex of the face
+	edges map[[2]int]int
+}
+
+// normalize scales v to unit length,
+// unlike vec3.T.Normalize() also if v is shorter than vec3.Epsilon.
+func normalize(v *vec3.T) {
+	if length := v.Length(); length != 0 {
+		v.Scale(1 / length)
+	}
+}
+
+func newPlane(a, b, c *vec3.T) Plane {
+	ab, ac := vec3.Sub(b, a), vec3.Sub(c, a)
+	normal := vec3.Cross(&ab, &ac)
+	normalize(&normal)
+	return Plane{Normal: normal, Distance: vec3.Dot(&normal, a)}
+}
+
+func (b *builder) addFace(v0, v1, v2 int) int {
+	f := len(b.faces)
+	b.faces = append(b.faces, face{
+		vertices: [3]int{v0, v1, v2},
+		plane:    newPlane(&b.points[v0], &b.points[v1], &b.points[v2]),
+	})
+	b.edges[[2]int{v0, v1}] = f
+	b.edges[[2]int{v1, v2}] = f
+	b.edges[[2]int{v2, v0}] = f
+	return f
+}
+
+// isAbove returns if the point is farther than epsilon in front of the face.
+// Points within the tolerance of the plane count as coplanar like in the thick planes of Barber et al.,
+// so that nearly coplanar points don't produce sliver triangles with unreliable planes.
+func (b *builder) isAbove(f int, point int) bool {
+	return b.faces[f].plane.SignedDistance(&b.points[point]) > b.epsilon
+}
+
+// farthest returns the point with the largest distance greater than minDist or -1.
+// Of the points within epsilon of the largest distance the one farthest from center is returned,
+// which is a corner of the convex hull of these nearly coplanar points,
+// the others could be on its edges and would become vertices between coplanar triangles.
+func (b *builder) farthest(points []int, distance func(point int) float64, minDist float64, center *vec3.T) int {
+	best, bestDist := -1, minDist
+	for _, i := range points {
+		if dist := distance(i); dist > bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	if best == -1 {
+		return -1
+	}
+	bestSqr := vec3.SquareDistance(&b.points[best], center)
+	for _, i := range points {
+		if distance(i) >= bestDist-b.epsilon {
+			if sqr := vec3.SquareDistance(&b.points[i], center); sqr > bestSqr {
+				best, bestSqr = i, sqr
+			}
+		}
+	}
+	return best
+}
+
+// assign adds the point to the outside set of the first face that it is in front of.
+// Points behind all faces are inside of the hull and are dropped.
+func (b *builder) assign(point int, faces []int) {
+	for _, f := range faces {
+		if b.isAbove(f, point) {
+			b.faces[f].outside = append(b.faces[f].outside, point)
+			return
+		}
+	}
+}
+
+func (b *builder) build(box *vec3.Box) {
+	diagonal := box.Diagonal()
+	if diagonal.LengthSqr() <= b.epsilon*b.epsilon {
+		// All points are equal
+		return
+	}
+
+	// The two extreme points along the axes that are farthest apart
+	var extremes [6]int
+	for i := range b.points {
+		for axis := 0; axis < 3; axis++ {
+			if b.points[i][axis] < b.points[extremes[2*axis]][axis] {
+				extremes[2*axis] = i
+			}
+			if b.points[i][axis] > b.points[extremes[2*axis+1]][axis] {
+				extremes[2*axis+1] = i
+			}
+		}
+	}
+	v0, v1 := extremes[0], extremes[1]
+	for _, i := range extremes {
+		for _, j := range extremes {
+			if vec3.SquareDistance(&b.points[i], &b.points[j]) > vec3.SquareDistance(&b.points[v0], &b.points[v1]) {
+				v0, v1 = i, j
+			}
+		}
+	}
+
+	// The point farthest from the line
+	all := make([]int, len(b.points))
+	for i := range all {
+		all[i] = i
+	}
+	p0, p1 := &b.points[v0], &b.points[v1]
+	line := vec3.Sub(p1, p0)
+	normalize(&line)
+	v2 := b.farthest(all, func(i int) float64 {
+		d := vec3.Sub(&b.points[i], p0)
+		cross := vec3.Cross(&d, &line)
+		return cross.Length()
+	}, b.epsilon, p0)
+	if v2 == -1 {
+		// All points are collinear
+		return
+	}
+
+	// The point farthest from the plane
+	plane := newPlane(p0, p1, &b.points[v2])
+	v3 := b.farthest(all, func(i int) float64 {
+		dist := plane.SignedDistance(&b.points[i])
+		return max(dist, -dist)
+	}, b.epsilon, p0)
+	if v3 == -1 {
+		b.buildFlat(v0, &plane)
+		return
+	}
+
+	// Initial tetrahedron with the triangle v0, v1, v2 facing away from v3
+	if plane.SignedDistance(&b.points[v3]) > 0 {
+		v1, v2 = v2, v1
+	}
+	b.edges = make(map[[2]int]int)
+	initial := []int{
+		b.addFace(v0, v1, v2),
+		b.addFace(v1, v0, v3),
+		b.addFace(v2, v1, v3),
+		b.addFace(v0, v2, v3),
+	}
+	for i := range b.points {
+		if i != v0 && i != v1 && i != v2 && i != v3 {
+			b.assign(i, initial)
+		}
+	}
+
+	var visible, newFaces []int
+	var horizon [][2]int
+	for f := 0; f < len(b.faces); f++ {
+		if b.faces[f].removed || len(b.faces[f].outside) == 0 {
+			continue
+		}
+		// The outside point farthest from the face is a vertex of the hull
+		plane := &b.faces[f].plane
+		eye := b.farthest(b.faces[f].outside, func(i int) float64 {
+			return plane.SignedDistance(&b.points[i])
+		}, b.epsilon, &b.points[b.faces[f].vertices[0]])
+
+		// Flood fill the faces that see the eye point,
+		// their edges to faces that don't see it are the horizon
+		visible = append(visible[:0], f)
+		horizon = horizon[:0]
+		b.faces[f].removed = true
+		for i := 0; i < len(visible); i++ {
+			vertices := b.faces[visible[i]].vertices
+			for j := 0; j < 3; j++ {
+				edge := [2]int{vertices[j], vertices[(j+1)%3]}
+				n := b.edges[[2]int{edge[1], edge[0]}]
+				if b.faces[n].removed {
+					continue
+				}
+				if b.isAbove(n, eye) {
+					b.faces[n].removed = true
+					visible = append(visible, n)
+					continue
+				}
+				horizon = append(horizon, edge)
+			}
+		}
+
+		// Replace the visible faces by faces connecting the horizon with the eye point
+		newFaces = newFaces[:0]
+		for _, edge := range horizon {
+			newFaces = append(newFaces, b.addFace(edge[0], edge[1], eye))
+		}
+		for _, v := range visible {
+			for _, i := range b.faces[v].outside {
+				if i != eye {
+					b.assign(i, newFaces)
+				}
+			}
+			b.faces[v].outside = nil
+		}
+	}
+}
+
+// buildFlat adds faces for the two sides of the convex hull of coplanar points.
+func (b *builder) buildFlat(origin int, plane *Plane) {
+	// Project the points onto the plane
+	u := vec3.Cross(&plane.Normal, &vec3.UnitX)
+	if u.LengthSqr() < 0.1 {
+		u = vec3.Cross(&plane.Normal, &vec3.UnitY)
+	}
+	normalize(&u)
+	v := vec3.Cross(&plane.Normal, &u)
+	projected := make([]vec2.T, len(b.points))
+	index := make(map[vec2.T]int, len(b.points))
+	for i := range b.points {
+		d := vec3.Sub(&b.points[i], &b.points[origin])
+		projected[i] = vec2.T{vec3.Dot(&d, &u), vec3.Dot(&d, &v)}
+		if _, ok := index[projected[i]]; !ok {
+			index[projected[i]] = i
+		}
+	}
+	polygon := polygon2.ConvexHull(projected)
+	if len(polygon) < 3 {
+		return
+	}
+	// u, v and the normal are a right handed basis,
+	// so the left winding polygon faces in the direction of the normal
+	b.edges = make(map[[2]int]int)
+	first := index[polygon[0]]
+	for i := 1; i < len(polygon)-1; i++ {
+		b.addFace(first, index[polygon[i]], index[polygon[i+1]])
+		b.addFace(first, index[polygon[i+1]], index[polygon[i]])
+	}
+}
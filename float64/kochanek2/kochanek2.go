@@ -0,0 +1,119 @@
+// Package kochanek2 contains a float64 type T for 2D Kochanek-Bartels splines,
+// also known as TCB splines, through a sequence of points.
+// See: https://en.wikipedia.org/wiki/Kochanek%E2%80%93Bartels_spline
+package kochanek2
+
+import (
+	"github.com/ungerik/go3d/float64/bezier2"
+	"github.com/ungerik/go3d/float64/hermit2"
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// T holds the data to define a Kochanek-Bartels spline that passes through all Points.
+// Segment i runs from Points[i] to Points[i+1] and is parameterized
+// by the global parameter t from i to i+1.
+// The first and last segments use mirrored end points as outer neighbours.
+// With Tension, Continuity and Bias of zero the spline is a uniform Catmull-Rom spline.
+type T struct {
+	Points []vec2.T
+	// Tension changes the length of the tangents,
+	// 1 results in sharp corners and -1 in rounder curves.
+	Tension float64
+	// Continuity changes the difference between incoming and outgoing tangents,
+	// values different from 0 result in corners.
+	Continuity float64
+	// Bias changes the direction of the tangents,
+	// 1 moves it towards the previous and -1 towards the next point.
+	Bias float64
+}
+
+// Segments returns the number of segments of the spline.
+func (spline *T) Segments() int {
+	return max(len(spline.Points)-1, 0)
+}
+
+// Point returns a point on the spline at the global parameter t (0,Segments()).
+func (spline *T) Point(t float64) vec2.T {
+	if len(spline.Points) < 2 {
+		if len(spline.Points) == 0 {
+			return vec2.Zero
+		}
+		return spline.Points[0]
+	}
+	i, local := segmentParam(t, spline.Segments())
+	herm := spline.Hermit(i)
+	return herm.Point(local)
+}
+
+// Tangent returns the tangent of the spline at the global parameter t (0,Segments()),
+// which is the first derivative with respect to t.
+func (spline *T) Tangent(t float64) vec2.T {
+	if len(spline.Points) < 2 {
+		return vec2.Zero
+	}
+	i, local := segmentParam(t, spline.Segments())
+	herm := spline.Hermit(i)
+	return herm.Tangent(local)
+}
+
+// Hermit returns segment i of the spline as hermit spline.
+func (spline *T) Hermit(i int) hermit2.T {
+	p1 := &spline.Points[i]
+	p2 := &spline.Points[i+1]
+	var p0, p3 vec2.T
+	if i > 0 {
+		p0 = spline.Points[i-1]
+	} else {
+		p0 = p1.Scaled(2)
+		p0.Sub(p2)
+	}
+	if i+2 < len(spline.Points) {
+		p3 = spline.Points[i+2]
+	} else {
+		p3 = p2.Scaled(2)
+		p3.Sub(p1)
+	}
+
+	in0 := vec2.Sub(p1, &p0)
+	out0 := vec2.Sub(p2, p1)
+	in1 := out0
+	out1 := vec2.Sub(&p3, p2)
+
+	ten := 1 - spline.Tension
+	con := spline.Continuity
+	bias := spline.Bias
+
+	// Outgoing tangent at p1
+	a := in0.Scaled(ten * (1 + bias) * (1 - con) / 2)
+	b := out0.Scaled(ten * (1 - bias) * (1 + con) / 2)
+	tangentA := vec2.Add(&a, &b)
+
+	// Incoming tangent at p2
+	a = in1.Scaled(ten * (1 + bias) * (1 + con) / 2)
+	b = out1.Scaled(ten * (1 - bias) * (1 - con) / 2)
+	tangentB := vec2.Add(&a, &b)
+
+	return hermit2.T{
+		A: hermit2.PointTangent{Point: *p1, Tangent: tangentA},
+		B: hermit2.PointTangent{Point: *p2, Tangent: tangentB},
+	}
+}
+
+// Bezier returns segment i of the spline as cubic bezier spline.
+func (spline *T) Bezier(i int) bezier2.T {
+	herm := spline.Hermit(i)
+	return herm.Bezier()
+}
+
+// segmentParam returns the index of the segment that contains the global parameter t
+// and the local parameter (0,1) within that segment.
+func segmentParam(t float64, segments int) (i int, local float64) {
+	if t <= 0 {
+		return 0, 0
+	}
+	if t >= float64(segments) {
+		return segments - 1, 1
+	}
+	i = int(t)
+	return i, t - float64(i)
+}
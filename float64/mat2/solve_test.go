@@ -0,0 +1,104 @@
+package mat2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// mul multiplies two matrices without relying on MulVec2.
+func mul(a, b *T) T {
+	var r T
+	for col := 0; col < 2; col++ {
+		for row := 0; row < 2; row++ {
+			r[col][row] = a[0][row]*b[col][0] + a[1][row]*b[col][1]
+		}
+	}
+	return r
+}
+
+func TestLU(t *testing.T) {
+	lu, err := invertableMatrix1.LU()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, u, p := lu.L(), lu.U(), lu.P()
+	pa := mul(&p, &invertableMatrix1)
+	lu2 := mul(&l, &u)
+	if !pa.PracticallyEquals(&lu2, EPSILON) {
+		t.Errorf("P*A != L*U: %v != %v", pa, lu2)
+	}
+	if det := lu.Determinant(); math.Abs(float64(det-4)) > EPSILON {
+		t.Errorf("wrong determinant: got %f, want 4", det)
+	}
+	inv := lu.Inverse()
+	if !inv.PracticallyEquals(&invertedMatrix1, EPSILON) {
+		t.Errorf("wrong inverse: got %v, want %v", inv, invertedMatrix1)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	// 4x + 8y = -12, -2x - 3y = 5
+	b := vec2.T{-12, 5}
+	x, condition, err := invertableMatrix1.Solve(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := vec2.T{-1, -1}
+	if !x.PracticallyEquals(&expected, EPSILON) {
+		t.Errorf("wrong solution: got %v, want %v", x, expected)
+	}
+	// |A|1 = 11, |A^-1|1 = 3
+	if math.Abs(float64(condition-33)) > EPSILON {
+		t.Errorf("wrong condition number: got %f, want 33", condition)
+	}
+}
+
+func TestSolveSingular(t *testing.T) {
+	b := vec2.T{1, 2}
+	// Singular up to rounding errors
+	rounded := T{{0.1, 0.3}, {0.7, 2.1}}
+	for _, m := range []T{nonInvertableMatrix1, nonInvertableMatrix2, Zero, rounded} {
+		if _, _, err := m.Solve(&b); err != ErrSingular {
+			t.Errorf("expected ErrSingular for %v, got %v", m, err)
+		}
+	}
+}
+
+func TestQR(t *testing.T) {
+	for _, m := range []T{invertableMatrix1, nonInvertableMatrix2, Ident} {
+		q, r := m.QR()
+		qt := q.Transposed()
+		if product := mul(&q, &qt); !product.PracticallyEquals(&Ident, EPSILON) {
+			t.Errorf("q of %v is not orthogonal: %v", m, q)
+		}
+		if r[0][1] != 0 {
+			t.Errorf("r of %v is not upper triangular: %v", m, r)
+		}
+		if product := mul(&q, &r); !product.PracticallyEquals(&m, EPSILON) {
+			t.Errorf("q*r != m: %v != %v", product, m)
+		}
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	// Regression line through points symmetric around y = 0.5x + 1
+	rows := []vec2.T{{0, 1}, {1, 1}, {2, 1}, {3, 1}}
+	b := []float64{1.1, 1.4, 2.1, 2.4}
+	x, residual, err := LeastSquares(rows, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := vec2.T{0.46, 1.06}
+	if !x.PracticallyEquals(&expected, EPSILON) {
+		t.Errorf("wrong solution: got %v, want %v", x, expected)
+	}
+	if residual <= 0 {
+		t.Errorf("residual of inconsistent system should be positive, got %f", residual)
+	}
+
+	if _, _, err := LeastSquares([]vec2.T{{1, 2}, {2, 4}, {3, 6}}, []float64{1, 2, 3}); err != ErrSingular {
+		t.Errorf("expected ErrSingular for rank deficient system, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package mat3
+
+import (
+	"errors"
+	"math"
+
+	"github.com/ungerik/go3d/float64/quaternion"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// Alignment holds the result of AlignPoints.
+// A point p is mapped onto the target point set by Scale * Rotation * p + Translation.
+type Alignment struct {
+	Rotation    T
+	Translation vec3.T
+	Scale       float64
+	// RMSD is the root mean square deviation of the aligned points from the target points.
+	RMSD float64
+}
+
+// Quaternion returns the rotation of the alignment as quaternion.
+func (align *Alignment) Quaternion() quaternion.T {
+	return align.Rotation.Quaternion()
+}
+
+// MulVec3 returns the point v transformed by the alignment.
+func (align *Alignment) MulVec3(v *vec3.T) vec3.T {
+	result := align.Rotation.MulVec3(v)
+	result.Scale(align.Scale)
+	return *result.Add(&align.Translation)
+}
+
+// TransformVec3 transforms the point v by the alignment and saves the result in v.
+func (align *Alignment) TransformVec3(v *vec3.T) {
+	*v = align.MulVec3(v)
+}
+
+// AlignPoints computes the rigid transformation that maps the points of from
+// onto the corresponding points of to with the minimal root mean square deviation
+// using the Kabsch algorithm.
+// If scaling is true, an optimal uniform scale factor is also computed (Umeyama's method),
+// else the Scale of the result is always 1.
+// The resulting Rotation is always a proper rotation, reflections are never returned.
+// If from contains less than three non collinear points the rotation around
+// the remaining free axes is arbitrary but still optimal.
+// An error is returned if the point sets have different lengths, are empty,
+// or if all points of from are identical.
+func AlignPoints(from, to []vec3.T, scaling bool) (Alignment, error) {
+	if len(from) != len(to) {
+		return Alignment{}, errors.New("can not align point sets of different length")
+	}
+	if len(from) == 0 {
+		return Alignment{}, errors.New("can not align empty point sets")
+	}
+
+	n := float64(len(from))
+	var centroidFrom, centroidTo vec3.T
+	for i := range from {
+		centroidFrom.Add(&from[i])
+		centroidTo.Add(&to[i])
+	}
+	centroidFrom.Scale(1 / n)
+	centroidTo.Scale(1 / n)
+
+	var covariance T
+	var varianceFrom float64
+	for i := range from {
+		dFrom := vec3.Sub(&from[i], &centroidFrom)
+		dTo := vec3.Sub(&to[i], &centroidTo)
+		varianceFrom += dFrom.LengthSqr()
+		for col := 0; col < 3; col++ {
+			for row := 0; row < 3; row++ {
+				covariance[col][row] += dTo[row] * dFrom[col]
+			}
+		}
+	}
+	covariance.Mul(1 / n)
+	varianceFrom /= n
+	if varianceFrom == 0 {
+		return Alignment{}, errors.New("can not align degenerate point set with all points identical")
+	}
+
+	u, s, v := covariance.SVD()
+	if u.Determinant()*v.Determinant() < 0 {
+		// Optimal orthogonal solution is a reflection,
+		// flip the axis with the smallest singular value
+		u[2].Invert()
+		s[2] = -s[2]
+	}
+
+	result := Alignment{Scale: 1}
+	vt := v.Transposed()
+	result.Rotation.AssignMul(&u, &vt)
+	if scaling {
+		result.Scale = (s[0] + s[1] + s[2]) / varianceFrom
+	}
+
+	rotated := result.Rotation.MulVec3(&centroidFrom)
+	rotated.Scale(result.Scale)
+	result.Translation = vec3.Sub(&centroidTo, &rotated)
+
+	var sumSqr float64
+	for i := range from {
+		p := result.MulVec3(&from[i])
+		sumSqr += vec3.SquareDistance(&p, &to[i])
+	}
+	result.RMSD = math.Sqrt(sumSqr / n)
+
+	return result, nil
+}
@@ -0,0 +1,104 @@
+package mat3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/quaternion"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+var alignPoints = []vec3.T{
+	{0, 0, 0},
+	{1, 0, 0},
+	{0, 2, 0},
+	{0, 0, 3},
+	{1, 1, 1},
+	{-1, 0.5, 2},
+}
+
+func transformedPoints(q *quaternion.T, scale float64, translation *vec3.T) []vec3.T {
+	result := make([]vec3.T, len(alignPoints))
+	for i := range alignPoints {
+		result[i] = q.RotatedVec3(&alignPoints[i])
+		result[i].Scale(scale).Add(translation)
+	}
+	return result
+}
+
+func TestAlignPoints(t *testing.T) {
+	axis := vec3.T{1, 2, -1}
+	axis.Normalize()
+	q := quaternion.FromAxisAngle(&axis, 1.3)
+	translation := vec3.T{5, -2, 0.5}
+	target := transformedPoints(&q, 1, &translation)
+
+	align, err := AlignPoints(alignPoints, target, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if align.Scale != 1 {
+		t.Errorf("scale should be 1 without scaling, got %f", align.Scale)
+	}
+	if !align.Translation.PracticallyEquals(&translation, EPSILON) {
+		t.Errorf("wrong translation: got %v, want %v", align.Translation, translation)
+	}
+	aq := align.Quaternion()
+	if math.Abs(float64(quaternion.Dot(&aq, &q))) < 1-EPSILON {
+		t.Errorf("wrong rotation: got %v, want %v", aq, q)
+	}
+	if align.RMSD > EPSILON {
+		t.Errorf("RMSD should be zero, got %f", align.RMSD)
+	}
+}
+
+func TestAlignPointsScaled(t *testing.T) {
+	q := quaternion.FromYAxisAngle(-0.6)
+	translation := vec3.T{-1, 0, 3}
+	target := transformedPoints(&q, 2.5, &translation)
+
+	align, err := AlignPoints(alignPoints, target, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(float64(align.Scale-2.5)) > EPSILON {
+		t.Errorf("wrong scale: got %f, want 2.5", align.Scale)
+	}
+	for i := range alignPoints {
+		p := align.MulVec3(&alignPoints[i])
+		if !p.PracticallyEquals(&target[i], 0.001) {
+			t.Errorf("point %d aligned to %v, want %v", i, p, target[i])
+		}
+	}
+}
+
+func TestAlignPointsReflection(t *testing.T) {
+	// Mirrored point set, the best proper rotation must not be a reflection
+	target := make([]vec3.T, len(alignPoints))
+	for i, p := range alignPoints {
+		target[i] = vec3.T{-p[0], p[1], p[2]}
+	}
+	align, err := AlignPoints(alignPoints, target, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if det := align.Rotation.Determinant(); math.Abs(float64(det-1)) > EPSILON {
+		t.Errorf("rotation has determinant %f", det)
+	}
+	if align.RMSD <= EPSILON {
+		t.Errorf("mirrored point set can not be aligned exactly, got RMSD %f", align.RMSD)
+	}
+}
+
+func TestAlignPointsErrors(t *testing.T) {
+	if _, err := AlignPoints(alignPoints, alignPoints[1:], false); err == nil {
+		t.Errorf("expected error for different lengths")
+	}
+	if _, err := AlignPoints(nil, nil, false); err == nil {
+		t.Errorf("expected error for empty point sets")
+	}
+	same := []vec3.T{{1, 2, 3}, {1, 2, 3}}
+	if _, err := AlignPoints(same, alignPoints[:2], true); err == nil {
+		t.Errorf("expected error for degenerate point set")
+	}
+}
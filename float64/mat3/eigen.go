@@ -0,0 +1,146 @@
+package mat3
+
+import (
+	"math"
+
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// maxJacobiSweeps limits the number of Jacobi sweeps.
+// A symmetric 3x3 matrix usually converges in less than 10 sweeps.
+const maxJacobiSweeps = 50
+
+// EigenSymmetric computes the eigenvalues and eigenvectors of a symmetric matrix
+// using the cyclic Jacobi method.
+// Only the lower triangle of the matrix is read, the upper triangle is assumed to mirror it.
+// The eigenvalues are sorted in descending order and the eigenvector
+// for values[i] is the column vectors[i].
+// The eigenvectors are orthonormal and form a right-handed coordinate system,
+// so vectors is a rotation matrix.
+func (mat *T) EigenSymmetric() (values vec3.T, vectors T) {
+	a := *mat
+	a[1][0] = a[0][1]
+	a[2][0] = a[0][2]
+	a[2][1] = a[1][2]
+	vectors = Ident
+
+	for sweep := 0; sweep < maxJacobiSweeps; sweep++ {
+		if a[0][1] == 0 && a[0][2] == 0 && a[1][2] == 0 {
+			break
+		}
+		for p := 0; p < 2; p++ {
+			for q := p + 1; q < 3; q++ {
+				apq := a[p][q]
+				if apq == 0 {
+					continue
+				}
+				// Off-diagonal element is negligible compared to the diagonal
+				g := 100 * math.Abs(apq)
+				if math.Abs(a[p][p])+g == math.Abs(a[p][p]) && math.Abs(a[q][q])+g == math.Abs(a[q][q]) {
+					a[p][q] = 0
+					a[q][p] = 0
+					continue
+				}
+
+				theta := (a[q][q] - a[p][p]) / (2 * apq)
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				for k := 0; k < 3; k++ {
+					akp := a[k][p]
+					akq := a[k][q]
+					a[k][p] = c*akp - s*akq
+					a[k][q] = s*akp + c*akq
+				}
+				for k := 0; k < 3; k++ {
+					apk := a[p][k]
+					aqk := a[q][k]
+					a[p][k] = c*apk - s*aqk
+					a[q][k] = s*apk + c*aqk
+				}
+				a[p][q] = 0
+				a[q][p] = 0
+
+				for k := 0; k < 3; k++ {
+					vpk := vectors[p][k]
+					vqk := vectors[q][k]
+					vectors[p][k] = c*vpk - s*vqk
+					vectors[q][k] = s*vpk + c*vqk
+				}
+			}
+		}
+	}
+
+	values = vec3.T{a[0][0], a[1][1], a[2][2]}
+
+	// Selection sort of three elements in descending order
+	for i := 0; i < 2; i++ {
+		max := i
+		for j := i + 1; j < 3; j++ {
+			if values[j] > values[max] {
+				max = j
+			}
+		}
+		if max != i {
+			values[i], values[max] = values[max], values[i]
+			vectors[i], vectors[max] = vectors[max], vectors[i]
+		}
+	}
+
+	if vectors.Determinant() < 0 {
+		vectors[2].Invert()
+	}
+
+	return values, vectors
+}
+
+// Covariance returns the centroid and the covariance matrix of points.
+// The covariance is normalized by the number of points (population covariance).
+// For an empty slice the centroid and covariance are zero.
+func Covariance(points []vec3.T) (centroid vec3.T, covariance T) {
+	if len(points) == 0 {
+		return vec3.Zero, Zero
+	}
+
+	for i := range points {
+		centroid.Add(&points[i])
+	}
+	centroid.Scale(1 / float64(len(points)))
+
+	for i := range points {
+		d := vec3.Sub(&points[i], &centroid)
+		covariance[0][0] += d[0] * d[0]
+		covariance[0][1] += d[0] * d[1]
+		covariance[0][2] += d[0] * d[2]
+		covariance[1][1] += d[1] * d[1]
+		covariance[1][2] += d[1] * d[2]
+		covariance[2][2] += d[2] * d[2]
+	}
+	covariance.Mul(1 / float64(len(points)))
+	covariance[1][0] = covariance[0][1]
+	covariance[2][0] = covariance[0][2]
+	covariance[2][1] = covariance[1][2]
+
+	return centroid, covariance
+}
+
+// PrincipalComponents performs a principal component analysis of points.
+// It returns the centroid of the points, the principal axes as columns
+// of a rotation matrix and the variances of the points along those axes.
+// The axes are sorted by descending variance, so axes[0] is the direction
+// of the largest spread and axes[2] is the normal of a best fitting plane.
+func PrincipalComponents(points []vec3.T) (centroid vec3.T, axes T, variances vec3.T) {
+	centroid, covariance := Covariance(points)
+	variances, axes = covariance.EigenSymmetric()
+	// Rounding can produce tiny negative variances for degenerate point sets
+	for i := range variances {
+		if variances[i] < 0 {
+			variances[i] = 0
+		}
+	}
+	return centroid, axes, variances
+}
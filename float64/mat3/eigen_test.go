@@ -0,0 +1,92 @@
+package mat3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+func TestEigenSymmetric(t *testing.T) {
+	var rotation T
+	rotation.AssignEulerRotation(0.3, -0.7, 1.1)
+	diagonal := T{
+		vec3.T{2, 0, 0},
+		vec3.T{0, 5, 0},
+		vec3.T{0, 0, -1},
+	}
+	transposed := rotation.Transposed()
+	var tmp, symmetric T
+	tmp.AssignMul(&rotation, &diagonal)
+	symmetric.AssignMul(&tmp, &transposed)
+
+	values, vectors := symmetric.EigenSymmetric()
+
+	expectedValues := vec3.T{5, 2, -1}
+	if !values.PracticallyEquals(&expectedValues, EPSILON) {
+		t.Errorf("wrong eigenvalues: got %v, want %v", values, expectedValues)
+	}
+	if det := vectors.Determinant(); math.Abs(float64(det-1)) > EPSILON {
+		t.Errorf("eigenvectors are not a rotation, determinant is %f", det)
+	}
+	for i := 0; i < 3; i++ {
+		av := symmetric.MulVec3(&vectors[i])
+		lv := vectors[i].Scaled(values[i])
+		if !av.PracticallyEquals(&lv, EPSILON) {
+			t.Errorf("A*v != lambda*v for eigenvector %d: %v != %v", i, av, lv)
+		}
+	}
+}
+
+func TestEigenSymmetricDiagonal(t *testing.T) {
+	diagonal := T{
+		vec3.T{1, 0, 0},
+		vec3.T{0, 3, 0},
+		vec3.T{0, 0, 2},
+	}
+	values, vectors := diagonal.EigenSymmetric()
+	if values != (vec3.T{3, 2, 1}) {
+		t.Errorf("wrong or unsorted eigenvalues: %v", values)
+	}
+	if vectors[0].Absed() != vec3.UnitY || vectors[1].Absed() != vec3.UnitZ || vectors[2].Absed() != vec3.UnitX {
+		t.Errorf("wrong eigenvectors: %v", vectors)
+	}
+}
+
+func TestPrincipalComponents(t *testing.T) {
+	// Points spread along the diagonal of the XY plane
+	points := []vec3.T{
+		{-2, -2, 0},
+		{-1, -1, 0.1},
+		{0, 0, -0.2},
+		{1, 1, 0.1},
+		{2, 2, 0},
+		{0.5, -0.5, 0},
+		{-0.5, 0.5, 0},
+	}
+	centroid, axes, variances := PrincipalComponents(points)
+
+	if !centroid.PracticallyEquals(&vec3.Zero, EPSILON) {
+		t.Errorf("wrong centroid: %v", centroid)
+	}
+	if !(variances[0] >= variances[1] && variances[1] >= variances[2]) {
+		t.Errorf("variances not sorted: %v", variances)
+	}
+	diagonal := vec3.T{1, 1, 0}
+	diagonal.Normalize()
+	major := axes[0].Absed()
+	if !major.PracticallyEquals(&diagonal, 0.01) {
+		t.Errorf("wrong major axis: %v", axes[0])
+	}
+	normal := axes[2].Absed()
+	if !normal.PracticallyEquals(&vec3.UnitZ, 0.05) {
+		t.Errorf("wrong plane normal: %v", axes[2])
+	}
+}
+
+func TestPrincipalComponentsEmpty(t *testing.T) {
+	centroid, axes, variances := PrincipalComponents(nil)
+	if centroid != vec3.Zero || axes != Ident || variances != vec3.Zero {
+		t.Errorf("unexpected result for empty points: %v %v %v", centroid, axes, variances)
+	}
+}
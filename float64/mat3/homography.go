@@ -0,0 +1,258 @@
+package mat3
+
+import (
+	"errors"
+	"math"
+
+	"github.com/ungerik/go3d/float64/vec2"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// MulVec2Projective multiplies the 2D point v as homogeneous vector (x, y, 1) with mat
+// and returns the result divided by its homogeneous coordinate.
+// This applies a projective 2D transformation like a homography.
+// Points that are mapped to infinity result in infinite or NaN components.
+func (mat *T) MulVec2Projective(v *vec2.T) vec2.T {
+	w := mat[0][2]*v[0] + mat[1][2]*v[1] + mat[2][2]
+	return vec2.T{
+		(mat[0][0]*v[0] + mat[1][0]*v[1] + mat[2][0]) / w,
+		(mat[0][1]*v[0] + mat[1][1]*v[1] + mat[2][1]) / w,
+	}
+}
+
+// TransformVec2Projective applies the projective 2D transformation mat to v
+// and saves the result in v. See MulVec2Projective().
+func (mat *T) TransformVec2Projective(v *vec2.T) {
+	*v = mat.MulVec2Projective(v)
+}
+
+// quadBasis returns the projective transformation that maps
+// (1,0,0), (0,1,0), (0,0,1) and (1,1,1) onto the four homogeneous points of quad.
+func quadBasis(quad *[4]vec2.T) (T, error) {
+	m := T{
+		vec3.T{quad[0][0], quad[0][1], 1},
+		vec3.T{quad[1][0], quad[1][1], 1},
+		vec3.T{quad[2][0], quad[2][1], 1},
+	}
+	p3 := vec3.T{quad[3][0], quad[3][1], 1}
+	lambda, _, err := m.Solve(&p3)
+	if err != nil || lambda[0] == 0 || lambda[1] == 0 || lambda[2] == 0 {
+		return Zero, errors.New("can not compute homography as three of the points are collinear")
+	}
+	m[0].Scale(lambda[0])
+	m[1].Scale(lambda[1])
+	m[2].Scale(lambda[2])
+	return m, nil
+}
+
+// Homography returns the projective transformation (homography) that maps
+// the four points from[i] exactly onto the four points to[i].
+// Apply the result to points with MulVec2Projective().
+// The result is normalized so that mat[2][2] is 1 if possible.
+// An error is returned if three points of either quad are collinear.
+func Homography(from, to *[4]vec2.T) (T, error) {
+	basisFrom, err := quadBasis(from)
+	if err != nil {
+		return Zero, err
+	}
+	basisTo, err := quadBasis(to)
+	if err != nil {
+		return Zero, err
+	}
+	inverse, err := basisFrom.Inverted()
+	if err != nil {
+		return Zero, err
+	}
+	var h T
+	h.AssignMul(&basisTo, &inverse)
+	h.normalizeHomography()
+	return h, nil
+}
+
+// HomographyDLT estimates the projective transformation (homography) that maps
+// the points from[i] onto the points to[i] with the normalized direct linear transformation.
+// At least four point pairs are needed, for more points the result is the
+// least squares solution of the algebraic error.
+// The result is normalized so that mat[2][2] is 1 if possible.
+// An error is returned if the point sets have different lengths,
+// contain less than four points or are degenerate.
+func HomographyDLT(from, to []vec2.T) (T, error) {
+	if len(from) != len(to) {
+		return Zero, errors.New("can not compute homography of point sets with different length")
+	}
+	if len(from) < 4 {
+		return Zero, errors.New("can not compute homography from less than four points")
+	}
+
+	normFrom, ok := normalizationTransform(from)
+	if !ok {
+		return Zero, errors.New("can not compute homography of degenerate point set")
+	}
+	normTo, ok := normalizationTransform(to)
+	if !ok {
+		return Zero, errors.New("can not compute homography of degenerate point set")
+	}
+
+	// Rows of the 2N x 9 DLT system A*h = 0
+	rows := make([][9]float64, 0, 2*len(from))
+	for i := range from {
+		p := normFrom.MulVec2Projective(&from[i])
+		q := normTo.MulVec2Projective(&to[i])
+		rows = append(rows,
+			[9]float64{-p[0], -p[1], -1, 0, 0, 0, q[0] * p[0], q[0] * p[1], q[0]},
+			[9]float64{0, 0, 0, -p[0], -p[1], -1, q[1] * p[0], q[1] * p[1], q[1]},
+		)
+	}
+
+	h, rank := nullVector9(rows)
+	if rank < 8 {
+		return Zero, errors.New("can not compute homography of degenerate point set")
+	}
+
+	// h holds the rows of the normalized homography
+	var hn T
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			hn[col][row] = h[row*3+col]
+		}
+	}
+
+	invNormTo, err := normTo.Inverted()
+	if err != nil {
+		return Zero, err
+	}
+	var tmp, result T
+	tmp.AssignMul(&hn, &normFrom)
+	result.AssignMul(&invNormTo, &tmp)
+	result.normalizeHomography()
+	return result, nil
+}
+
+// normalizationTransform returns a similarity transformation that moves the centroid
+// of points to the origin and scales their mean distance from it to sqrt(2).
+func normalizationTransform(points []vec2.T) (T, bool) {
+	var centroid vec2.T
+	for i := range points {
+		centroid.Add(&points[i])
+	}
+	centroid.Scale(1 / float64(len(points)))
+	var meanDist float64
+	for i := range points {
+		d := vec2.Sub(&points[i], &centroid)
+		meanDist += d.Length()
+	}
+	meanDist /= float64(len(points))
+	if meanDist == 0 {
+		return Zero, false
+	}
+	s := math.Sqrt2 / meanDist
+	return T{
+		vec3.T{s, 0, 0},
+		vec3.T{0, s, 0},
+		vec3.T{-s * centroid[0], -s * centroid[1], 1},
+	}, true
+}
+
+// normalizeHomography scales a homography so that mat[2][2] is 1,
+// or to unit Frobenius norm if mat[2][2] is zero.
+func (mat *T) normalizeHomography() {
+	if mat[2][2] != 0 {
+		mat.Mul(1 / mat[2][2])
+		return
+	}
+	var sum float64
+	for col := range mat {
+		sum += mat[col].LengthSqr()
+	}
+	if sum > 0 {
+		mat.Mul(1 / math.Sqrt(sum))
+	}
+}
+
+// nullVector9 returns the unit right singular vector of the smallest singular value
+// of the matrix with the given rows and 9 columns, and the numerical rank of the matrix.
+// The singular values are computed with the one-sided Jacobi method like in SVD()
+// directly from the matrix, because forming the normal matrix would square
+// the condition and lose the small singular values of thin point sets.
+func nullVector9(rows [][9]float64) (vector [9]float64, rank int) {
+	const n = 9
+	m := len(rows)
+	var a [n][]float64
+	for col := range a {
+		a[col] = make([]float64, m)
+		for row := range rows {
+			a[col][row] = rows[row][col]
+		}
+	}
+	var v [n][n]float64
+	for i := 0; i < n; i++ {
+		v[i][i] = 1
+	}
+
+	for sweep := 0; sweep < maxJacobiSweeps; sweep++ {
+		rotated := false
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				var alpha, beta, gamma float64
+				for k := 0; k < m; k++ {
+					alpha += a[p][k] * a[p][k]
+					beta += a[q][k] * a[q][k]
+					gamma += a[p][k] * a[q][k]
+				}
+				if gamma == 0 || math.Abs(gamma) <= machineEpsilon*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				sn := c * t
+
+				for k := 0; k < m; k++ {
+					apk := a[p][k]
+					aqk := a[q][k]
+					a[p][k] = c*apk - sn*aqk
+					a[q][k] = sn*apk + c*aqk
+				}
+				for k := 0; k < n; k++ {
+					vpk := v[p][k]
+					vqk := v[q][k]
+					v[p][k] = c*vpk - sn*vqk
+					v[q][k] = sn*vpk + c*vqk
+				}
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+
+	// The singular values are the lengths of the rotated columns
+	var s [n]float64
+	min := 0
+	max := 0
+	for i := 0; i < n; i++ {
+		for k := 0; k < m; k++ {
+			s[i] += a[i][k] * a[i][k]
+		}
+		s[i] = math.Sqrt(s[i])
+		if s[i] < s[min] {
+			min = i
+		}
+		if s[i] > s[max] {
+			max = i
+		}
+	}
+	// Singular values within the rounding errors of the largest one are zero
+	threshold := float64(m) * machineEpsilon * s[max]
+	for i := 0; i < n; i++ {
+		if s[i] > threshold {
+			rank++
+		}
+	}
+	return v[min], rank
+}
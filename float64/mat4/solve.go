@@ -0,0 +1,324 @@
+package mat4
+
+import (
+	"errors"
+	"math"
+
+	"github.com/ungerik/go3d/float64/vec4"
+)
+
+// machineEpsilon is the difference between 1 and the next representable float value.
+var machineEpsilon = math.Nextafter(1, 2) - 1
+
+// ErrSingular is returned when a linear system can not be solved
+// because the matrix is singular or rank deficient.
+var ErrSingular = errors.New("matrix is singular")
+
+// LU holds the LU decomposition with partial pivoting P * A = L * U of a matrix A.
+// Use T.LU() to create it.
+type LU struct {
+	// lu holds L below and U on and above the diagonal in row-major order.
+	// The unit diagonal of L is implied.
+	lu    T
+	pivot [4]int
+	sign  float64
+	norm1 float64
+}
+
+// LU computes the LU decomposition with partial pivoting of the matrix.
+// ErrSingular is returned if the matrix is singular or if a pivot is
+// within the rounding errors of the 1-norm of the matrix,
+// the returned LU can still be used for Determinant() in that case.
+func (mat *T) LU() (LU, error) {
+	d := LU{
+		lu:    *mat,
+		pivot: [4]int{0, 1, 2, 3},
+		sign:  1,
+		norm1: mat.norm1(),
+	}
+	d.lu.Transpose()
+	var err error
+	a := &d.lu
+	for k := range a {
+		p := k
+		for i := k + 1; i < len(a); i++ {
+			if math.Abs(a[i][k]) > math.Abs(a[p][k]) {
+				p = i
+			}
+		}
+		if p != k {
+			a[p], a[k] = a[k], a[p]
+			d.pivot[p], d.pivot[k] = d.pivot[k], d.pivot[p]
+			d.sign = -d.sign
+		}
+		// Pivots this small compared to the matrix are rounding errors of a singular matrix
+		if math.Abs(a[k][k]) <= float64(len(a))*machineEpsilon*d.norm1 {
+			err = ErrSingular
+			if a[k][k] == 0 {
+				continue
+			}
+		}
+		for i := k + 1; i < len(a); i++ {
+			f := a[i][k] / a[k][k]
+			a[i][k] = f
+			for j := k + 1; j < len(a); j++ {
+				a[i][j] -= f * a[k][j]
+			}
+		}
+	}
+	return d, err
+}
+
+// L returns the lower triangular matrix with unit diagonal of the decomposition.
+func (lu *LU) L() T {
+	l := Ident
+	for row := range lu.lu {
+		for col := 0; col < row; col++ {
+			l[col][row] = lu.lu[row][col]
+		}
+	}
+	return l
+}
+
+// U returns the upper triangular matrix of the decomposition.
+func (lu *LU) U() T {
+	var u T
+	for row := range lu.lu {
+		for col := row; col < len(lu.lu); col++ {
+			u[col][row] = lu.lu[row][col]
+		}
+	}
+	return u
+}
+
+// P returns the row permutation matrix of the decomposition.
+func (lu *LU) P() T {
+	var p T
+	for row, col := range lu.pivot {
+		p[col][row] = 1
+	}
+	return p
+}
+
+// Determinant returns the determinant of the decomposed matrix.
+func (lu *LU) Determinant() float64 {
+	det := lu.sign
+	for i := range lu.lu {
+		det *= lu.lu[i][i]
+	}
+	return det
+}
+
+// Solve solves A * x = b for x, where A is the decomposed matrix.
+// The result is undefined if the matrix is singular.
+func (lu *LU) Solve(b *vec4.T) vec4.T {
+	a := &lu.lu
+	var x vec4.T
+	for i := range x {
+		x[i] = b[lu.pivot[i]]
+		for j := 0; j < i; j++ {
+			x[i] -= a[i][j] * x[j]
+		}
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := i + 1; j < len(x); j++ {
+			x[i] -= a[i][j] * x[j]
+		}
+		x[i] /= a[i][i]
+	}
+	return x
+}
+
+// Inverse returns the inverse of the decomposed matrix.
+// The result is undefined if the matrix is singular.
+func (lu *LU) Inverse() T {
+	var inv T
+	for col := range inv {
+		var e vec4.T
+		e[col] = 1
+		inv[col] = lu.Solve(&e)
+	}
+	return inv
+}
+
+// ConditionNumber returns the condition number of the decomposed matrix
+// in the 1-norm, which is the factor by which relative errors of b
+// can be amplified in the solution x of A * x = b.
+// Returns +Inf for a singular matrix.
+func (lu *LU) ConditionNumber() float64 {
+	for i := range lu.lu {
+		if lu.lu[i][i] == 0 {
+			return math.Inf(1)
+		}
+	}
+	inv := lu.Inverse()
+	return lu.norm1 * inv.norm1()
+}
+
+// Solve solves the linear system mat * x = b for x using LU decomposition
+// with partial pivoting, which is faster and more accurate than multiplying
+// b with the inverted matrix.
+// Also returns the condition number of the matrix, see LU.ConditionNumber().
+// ErrSingular is returned if the matrix is singular up to rounding errors, see LU().
+func (mat *T) Solve(b *vec4.T) (x vec4.T, condition float64, err error) {
+	lu, err := mat.LU()
+	if err != nil {
+		return vec4.Zero, math.Inf(1), err
+	}
+	return lu.Solve(b), lu.ConditionNumber(), nil
+}
+
+// norm1 returns the maximum absolute column sum of the matrix.
+func (mat *T) norm1() float64 {
+	var norm float64
+	for col := range mat {
+		var sum float64
+		for row := range mat[col] {
+			sum += math.Abs(mat[col][row])
+		}
+		if sum > norm {
+			norm = sum
+		}
+	}
+	return norm
+}
+
+// QR computes the QR decomposition mat = q * r using Householder reflections,
+// where q is orthogonal and r is upper triangular.
+func (mat *T) QR() (q, r T) {
+	// Work on rows of r to apply the reflections
+	r = *mat
+	r.Transpose()
+	q = Ident
+	n := len(r)
+	for k := 0; k < n-1; k++ {
+		var v vec4.T
+		var norm float64
+		for i := k; i < n; i++ {
+			v[i] = r[i][k]
+			norm += v[i] * v[i]
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			continue
+		}
+		if v[k] > 0 {
+			norm = -norm
+		}
+		v[k] -= norm
+		var vv float64
+		for i := k; i < n; i++ {
+			vv += v[i] * v[i]
+		}
+
+		// r = (I - 2*v*vT/vv) * r
+		for j := k; j < n; j++ {
+			var s float64
+			for i := k; i < n; i++ {
+				s += v[i] * r[i][j]
+			}
+			s *= 2 / vv
+			for i := k; i < n; i++ {
+				r[i][j] -= s * v[i]
+			}
+		}
+		// q = q * (I - 2*v*vT/vv), q is column-major
+		for row := 0; row < n; row++ {
+			var s float64
+			for i := k; i < n; i++ {
+				s += q[i][row] * v[i]
+			}
+			s *= 2 / vv
+			for i := k; i < n; i++ {
+				q[i][row] -= s * v[i]
+			}
+		}
+		for i := k + 1; i < n; i++ {
+			r[i][k] = 0
+		}
+	}
+	r.Transpose()
+	return q, r
+}
+
+// LeastSquares solves the overdetermined linear system A * x = b in the least squares sense,
+// minimizing the length of A * x - b, using Householder QR decomposition.
+// The rows of the matrix A are given by rows and len(b) must equal len(rows).
+// Returns the solution x and the length of the residual vector A * x - b.
+// ErrSingular is returned if there are less rows than unknowns
+// or if A does not have full column rank.
+func LeastSquares(rows []vec4.T, b []float64) (x vec4.T, residual float64, err error) {
+	if len(rows) != len(b) {
+		return vec4.Zero, 0, errors.New("number of rows and right hand side values differ")
+	}
+	n := len(x)
+	m := len(rows)
+	if m < n {
+		return vec4.Zero, 0, ErrSingular
+	}
+	a := make([]vec4.T, m)
+	copy(a, rows)
+	y := make([]float64, m)
+	copy(y, b)
+
+	var maxDiag float64
+	for k := 0; k < n; k++ {
+		var norm float64
+		for i := k; i < m; i++ {
+			norm += a[i][k] * a[i][k]
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			return vec4.Zero, 0, ErrSingular
+		}
+		if a[k][k] > 0 {
+			norm = -norm
+		}
+		// Householder vector v is stored in column k of a, starting at row k
+		a[k][k] -= norm
+		var vv float64
+		for i := k; i < m; i++ {
+			vv += a[i][k] * a[i][k]
+		}
+		for j := k + 1; j < n; j++ {
+			var s float64
+			for i := k; i < m; i++ {
+				s += a[i][k] * a[i][j]
+			}
+			s *= 2 / vv
+			for i := k; i < m; i++ {
+				a[i][j] -= s * a[i][k]
+			}
+		}
+		var s float64
+		for i := k; i < m; i++ {
+			s += a[i][k] * y[i]
+		}
+		s *= 2 / vv
+		for i := k; i < m; i++ {
+			y[i] -= s * a[i][k]
+		}
+		// Diagonal element of R
+		a[k][k] = norm
+		if math.Abs(norm) > maxDiag {
+			maxDiag = math.Abs(norm)
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		if math.Abs(a[k][k]) <= float64(m)*machineEpsilon*maxDiag {
+			return vec4.Zero, 0, ErrSingular
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		x[i] = y[i]
+		for j := i + 1; j < n; j++ {
+			x[i] -= a[i][j] * x[j]
+		}
+		x[i] /= a[i][i]
+	}
+	for i := n; i < m; i++ {
+		residual += y[i] * y[i]
+	}
+	return x, math.Sqrt(residual), nil
+}
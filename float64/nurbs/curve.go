@@ -0,0 +1,274 @@
+// Package nurbs contains float64 types for 3D non-uniform rational B-spline (NURBS)
+// curves and surfaces, which can represent conic sections exactly.
+// Control points are stored as homogeneous vec4.T with the weight as W
+// and the position multiplied by the weight in XYZ.
+// See: https://en.wikipedia.org/wiki/Non-uniform_rational_B-spline
+package nurbs
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/ungerik/go3d/float64/vec3"
+	"github.com/ungerik/go3d/float64/vec4"
+)
+
+// Curve is a NURBS curve of arbitrary degree.
+// The number of knots must be len(ControlPoints) + Degree + 1
+// and the knots must be in ascending order.
+type Curve struct {
+	Degree int
+	// ControlPoints holds the homogeneous control points (w*x, w*y, w*z, w).
+	ControlPoints []vec4.T
+	Knots         []float64
+}
+
+// Homogeneous returns the homogeneous control point (w*x, w*y, w*z, w)
+// for the position p with the weight w.
+func Homogeneous(p *vec3.T, w float64) vec4.T {
+	return vec4.T{p[0] * w, p[1] * w, p[2] * w, w}
+}
+
+// NewCurve returns a NURBS curve with the control points points, their weights and knots.
+// If weights is nil, all weights are 1. If knots is nil,
+// a clamped uniform knot vector is used, see ClampedKnots().
+// An error is returned if the number of weights or knots does not match the points.
+func NewCurve(degree int, points []vec3.T, weights []float64, knots []float64) (Curve, error) {
+	if weights != nil && len(weights) != len(points) {
+		return Curve{}, errors.New("number of weights and points differ")
+	}
+	if knots == nil {
+		knots = ClampedKnots(degree, len(points))
+	}
+	curve := Curve{
+		Degree:        degree,
+		ControlPoints: make([]vec4.T, len(points)),
+		Knots:         knots,
+	}
+	for i := range points {
+		w := float64(1)
+		if weights != nil {
+			w = weights[i]
+		}
+		curve.ControlPoints[i] = Homogeneous(&points[i], w)
+	}
+	return curve, curve.Validate()
+}
+
+// ClampedKnots returns a clamped uniform knot vector from 0 to 1
+// for count control points of a curve with degree,
+// so that the curve starts at the first and ends at the last control point.
+func ClampedKnots(degree, count int) []float64 {
+	if count <= degree {
+		return nil
+	}
+	knots := make([]float64, count+degree+1)
+	spans := count - degree
+	for i := range knots {
+		switch {
+		case i <= degree:
+			knots[i] = 0
+		case i >= count:
+			knots[i] = 1
+		default:
+			knots[i] = float64(i-degree) / float64(spans)
+		}
+	}
+	return knots
+}
+
+// Validate returns an error if the degree, number of control points or knots are not consistent.
+func (curve *Curve) Validate() error {
+	return validate(curve.Degree, len(curve.ControlPoints), curve.Knots)
+}
+
+func validate(degree, count int, knots []float64) error {
+	if degree < 1 {
+		return errors.New("degree must be at least 1")
+	}
+	if count <= degree {
+		return errors.New("number of control points must be greater than the degree")
+	}
+	if len(knots) != count+degree+1 {
+		return errors.New("number of knots must be number of control points + degree + 1")
+	}
+	for i := 1; i < len(knots); i++ {
+		if knots[i] < knots[i-1] {
+			return errors.New("knots must be in ascending order")
+		}
+	}
+	if knots[degree] == knots[count] {
+		return errors.New("knot vector has an empty domain")
+	}
+	return nil
+}
+
+// Domain returns the range of the curve parameter.
+func (curve *Curve) Domain() (t0, t1 float64) {
+	return curve.Knots[curve.Degree], curve.Knots[len(curve.ControlPoints)]
+}
+
+// HomogeneousPoint returns the homogeneous point of the curve at t
+// using the de Boor algorithm. t is clamped to the Domain().
+func (curve *Curve) HomogeneousPoint(t float64) vec4.T {
+	return deBoor(curve.Degree, curve.ControlPoints, curve.Knots, t)
+}
+
+// Point returns the point of the curve at t. t is clamped to the Domain().
+func (curve *Curve) Point(t float64) vec3.T {
+	h := curve.HomogeneousPoint(t)
+	return h.Vec3DividedByW()
+}
+
+// Tangent returns the first derivative of the curve at t.
+// t is clamped to the Domain().
+func (curve *Curve) Tangent(t float64) vec3.T {
+	_, d1, _ := curve.derivatives(t, 1)
+	return d1
+}
+
+// SecondDerivative returns the second derivative of the curve at t.
+// t is clamped to the Domain().
+func (curve *Curve) SecondDerivative(t float64) vec3.T {
+	_, _, d2 := curve.derivatives(t, 2)
+	return d2
+}
+
+// derivatives returns the point and the first and second derivative up to order
+// of the rational curve from the derivatives of the homogeneous curve.
+func (curve *Curve) derivatives(t float64, order int) (point, d1, d2 vec3.T) {
+	a := curve.HomogeneousPoint(t)
+	w := a[3]
+	point = a.Vec3DividedByW()
+	if order < 1 {
+		return point, d1, d2
+	}
+	degree, points, knots := derivativeCurve(curve.Degree, curve.ControlPoints, curve.Knots)
+	a1 := deBoor(degree, points, knots, t)
+	w1 := a1[3]
+	// C' = (A' - w'*C) / w
+	d1 = a1.Vec3()
+	wc := point.Scaled(w1)
+	d1.Sub(&wc).Scale(1 / w)
+	if order < 2 {
+		return point, d1, d2
+	}
+	var a2 vec4.T
+	if degree > 0 {
+		degree, points, knots = derivativeCurve(degree, points, knots)
+		a2 = deBoor(degree, points, knots, t)
+	}
+	w2 := a2[3]
+	// C'' = (A'' - 2*w'*C' - w''*C) / w
+	d2 = a2.Vec3()
+	wd1 := d1.Scaled(2 * w1)
+	wc = point.Scaled(w2)
+	d2.Sub(&wd1).Sub(&wc).Scale(1 / w)
+	return point, d1, d2
+}
+
+// InsertKnot inserts the knot t into the knot vector without changing the shape
+// of the curve using Boehm's algorithm. This adds one control point.
+// An error is returned if t is outside of the Domain()
+// or if the multiplicity of the knot would exceed the degree.
+func (curve *Curve) InsertKnot(t float64) error {
+	p := curve.Degree
+	t0, t1 := curve.Domain()
+	if t < t0 || t > t1 {
+		return errors.New("knot is outside of the curve domain")
+	}
+	multiplicity := 0
+	for _, k := range curve.Knots {
+		if k == t {
+			multiplicity++
+		}
+	}
+	if multiplicity >= p {
+		return errors.New("knot multiplicity would exceed the degree")
+	}
+
+	k := findSpan(p, len(curve.ControlPoints), curve.Knots, t)
+	points := make([]vec4.T, len(curve.ControlPoints)+1)
+	copy(points, curve.ControlPoints[:k-p+1])
+	copy(points[k+1:], curve.ControlPoints[k:])
+	for i := k - p + 1; i <= k; i++ {
+		a := (t - curve.Knots[i]) / (curve.Knots[i+p] - curve.Knots[i])
+		points[i] = vec4.Interpolate(&curve.ControlPoints[i-1], &curve.ControlPoints[i], a)
+	}
+
+	knots := make([]float64, len(curve.Knots)+1)
+	copy(knots, curve.Knots[:k+1])
+	knots[k+1] = t
+	copy(knots[k+2:], curve.Knots[k+1:])
+
+	curve.ControlPoints = points
+	curve.Knots = knots
+	return nil
+}
+
+// findSpan returns the index k of the knot span with knots[k] <= t < knots[k+1]
+// for a curve with count control points, where t is clamped to the domain.
+func findSpan(degree, count int, knots []float64, t float64) int {
+	if t >= knots[count] {
+		// Last non empty span for the end of the domain
+		k := count - 1
+		for k > degree && knots[k] == knots[count] {
+			k--
+		}
+		return k
+	}
+	if t <= knots[degree] {
+		return degree
+	}
+	// First knot greater than t in knots[degree+1 : count+1]
+	i := sort.Search(count-degree, func(i int) bool { return knots[degree+1+i] > t })
+	return degree + i
+}
+
+// deBoor evaluates the homogeneous B-spline at t.
+func deBoor(degree int, points []vec4.T, knots []float64, t float64) vec4.T {
+	if degree == 0 {
+		return points[findSpan(0, len(points), knots, t)]
+	}
+	k := findSpan(degree, len(points), knots, t)
+	t0, t1 := knots[degree], knots[len(points)]
+	t = max(t0, min(t1, t))
+
+	var buf [8]vec4.T
+	var d []vec4.T
+	if degree+1 <= len(buf) {
+		d = buf[:degree+1]
+	} else {
+		d = make([]vec4.T, degree+1)
+	}
+	copy(d, points[k-degree:k+1])
+	for r := 1; r <= degree; r++ {
+		for j := degree; j >= r; j-- {
+			i := k - degree + j
+			denom := knots[i+degree+1-r] - knots[i]
+			var a float64
+			if denom != 0 {
+				a = (t - knots[i]) / denom
+			}
+			d[j] = vec4.Interpolate(&d[j-1], &d[j], a)
+		}
+	}
+	return d[degree]
+}
+
+// derivativeCurve returns the homogeneous B-spline of degree-1
+// that is the derivative of the homogeneous B-spline with the given degree.
+func derivativeCurve(degree int, points []vec4.T, knots []float64) (int, []vec4.T, []float64) {
+	derived := make([]vec4.T, len(points)-1)
+	for i := range derived {
+		denom := knots[i+degree+1] - knots[i+1]
+		if denom == 0 {
+			continue
+		}
+		f := float64(degree) / denom
+		for c := range derived[i] {
+			derived[i][c] = f * (points[i+1][c] - points[i][c])
+		}
+	}
+	return degree - 1, derived, knots[1 : len(knots)-1]
+}
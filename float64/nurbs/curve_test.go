@@ -0,0 +1,154 @@
+package nurbs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/bezier3"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+const EPSILON = 1e-7
+
+var bezierPoints = []vec3.T{{0, 0, 0}, {1, 2, 0}, {3, 2, 1}, {4, 0, 1}}
+
+func TestNewCurveErrors(t *testing.T) {
+	if _, err := NewCurve(3, bezierPoints, []float64{1, 1}, nil); err == nil {
+		t.Errorf("expected error for wrong number of weights")
+	}
+	if _, err := NewCurve(3, bezierPoints, nil, []float64{0, 0, 1, 1}); err == nil {
+		t.Errorf("expected error for wrong number of knots")
+	}
+	if _, err := NewCurve(3, bezierPoints, nil, []float64{0, 0, 0, 0, 1, 1, 0.5, 1}); err == nil {
+		t.Errorf("expected error for descending knots")
+	}
+	if _, err := NewCurve(4, bezierPoints, nil, nil); err == nil {
+		t.Errorf("expected error for degree greater than number of control points")
+	}
+	if _, err := NewCurve(0, bezierPoints, nil, nil); err == nil {
+		t.Errorf("expected error for degree 0")
+	}
+}
+
+func TestBezierEquivalence(t *testing.T) {
+	// A non rational curve with clamped knots and degree+1 points is a bezier spline
+	curve, err := NewCurve(3, bezierPoints, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bez := bezier3.T{P0: bezierPoints[0], P1: bezierPoints[1], P2: bezierPoints[2], P3: bezierPoints[3]}
+	for _, s := range []float64{0, 0.2, 0.5, 0.7, 1} {
+		if got, want := curve.Point(s), bez.Point(s); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("point at %f failed: got %v, want %v", s, got, want)
+		}
+		if got, want := curve.Tangent(s), bez.Tangent(s); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("tangent at %f failed: got %v, want %v", s, got, want)
+		}
+		if got, want := curve.SecondDerivative(s), bez.SecondDerivative(s); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("second derivative at %f failed: got %v, want %v", s, got, want)
+		}
+	}
+	if got := curve.Point(-1); got != bezierPoints[0] {
+		t.Errorf("parameter should be clamped to the domain, got %v", got)
+	}
+}
+
+func TestInsertKnot(t *testing.T) {
+	weights := []float64{1, 0.5, 2, 1, 1.5}
+	points := append(bezierPoints, vec3.T{5, -1, 2})
+	curve, err := NewCurve(3, points, weights, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := curve
+	for _, knot := range []float64{0.3, 0.5, 0.5, 0.9} {
+		if err := curve.InsertKnot(knot); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(curve.ControlPoints) != len(points)+4 || curve.Validate() != nil {
+		t.Errorf("wrong number of control points or invalid curve after knot insertion: %v", curve)
+	}
+	for s := float64(0); s <= 1; s += 1.0 / 32 {
+		if got, want := curve.Point(s), original.Point(s); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("knot insertion changed the curve at %f: got %v, want %v", s, got, want)
+		}
+	}
+
+	if err := curve.InsertKnot(1.5); err == nil {
+		t.Errorf("expected error for knot outside of the domain")
+	}
+	if err := curve.InsertKnot(0.5); err == nil {
+		t.Errorf("expected error for knot with multiplicity greater than the degree")
+	}
+}
+
+func TestRationalDerivatives(t *testing.T) {
+	curve, err := NewCurve(3, bezierPoints, []float64{1, 3, 0.5, 1}, []float64{0, 0, 0, 0, 1, 1, 1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const h = 0.001
+	for _, s := range []float64{0.2, 0.5, 0.8} {
+		p0 := curve.Point(s - h)
+		p1 := curve.Point(s + h)
+		d1 := vec3.Sub(&p1, &p0)
+		d1.Scale(1 / (2 * h))
+		if got := curve.Tangent(s); !got.PracticallyEquals(&d1, 0.01) {
+			t.Errorf("tangent at %f failed: got %v, differences %v", s, got, d1)
+		}
+		t0 := curve.Tangent(s - h)
+		t1 := curve.Tangent(s + h)
+		d2 := vec3.Sub(&t1, &t0)
+		d2.Scale(1 / (2 * h))
+		if got := curve.SecondDerivative(s); !got.PracticallyEquals(&d2, 0.1) {
+			t.Errorf("second derivative at %f failed: got %v, differences %v", s, got, d2)
+		}
+	}
+}
+
+func TestArc(t *testing.T) {
+	center := vec3.T{1, 1, 0}
+	xAxis := vec3.T{2, 0, 0}
+	yAxis := vec3.T{0, 2, 0}
+	for _, sweep := range []float64{0.5, math.Pi / 2, 2, math.Pi, 2 * math.Pi} {
+		arc := Arc(&center, &xAxis, &yAxis, 0.3, 0.3+sweep)
+		if err := arc.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		start := arc.Point(0)
+		wantStart := vec3.T{1 + 2*float64(math.Cos(0.3)), 1 + 2*float64(math.Sin(0.3)), 0}
+		if !start.PracticallyEquals(&wantStart, EPSILON) {
+			t.Errorf("arc with sweep %f starts at %v, want %v", sweep, start, wantStart)
+		}
+		for s := float64(0); s <= 1; s += 1.0 / 64 {
+			p := arc.Point(s)
+			radius := vec3.Sub(&p, &center)
+			if math.Abs(radius.Length()-2) > EPSILON {
+				t.Errorf("point %v of arc with sweep %f is not on the circle", p, sweep)
+			}
+			tangent := arc.Tangent(s)
+			if cos := vec3.Dot(&radius, &tangent) / (radius.Length() * tangent.Length()); math.Abs(cos) > EPSILON {
+				t.Errorf("tangent %v of arc with sweep %f is not orthogonal to the radius", tangent, sweep)
+			}
+		}
+	}
+}
+
+func TestConic(t *testing.T) {
+	p0 := vec3.T{1, 0, 0}
+	p1 := vec3.T{1, 1, 0}
+	p2 := vec3.T{0, 1, 0}
+	quarter := Conic(&p0, &p1, &p2, float64(math.Sqrt2/2))
+	for s := float64(0); s <= 1; s += 1.0 / 16 {
+		if p := quarter.Point(s); math.Abs(p.Length()-1) > EPSILON {
+			t.Errorf("point %v of quarter circle is not on the unit circle", p)
+		}
+	}
+
+	// Weight 1 is a quadratic bezier spline, which is a parabola
+	parabola := Conic(&p0, &p1, &p2, 1)
+	if got, want := parabola.Point(0.5), (vec3.T{0.75, 0.75, 0}); !got.PracticallyEquals(&want, EPSILON) {
+		t.Errorf("parabola point failed: got %v, want %v", got, want)
+	}
+}
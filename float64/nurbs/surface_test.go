@@ -0,0 +1,99 @@
+package nurbs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec3"
+	"github.com/ungerik/go3d/float64/vec4"
+)
+
+func TestBilinearSurface(t *testing.T) {
+	points := [][]vec3.T{
+		{{0, 0, 0}, {0, 2, 0}},
+		{{3, 0, 0}, {3, 2, 0}},
+	}
+	surface, err := NewSurface(1, 1, points, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := surface.Point(0.5, 0.25), (vec3.T{1.5, 0.5, 0}); !got.PracticallyEquals(&want, EPSILON) {
+		t.Errorf("point failed: got %v, want %v", got, want)
+	}
+	_, du, dv := surface.Derivatives(0.3, 0.6)
+	if want := (vec3.T{3, 0, 0}); !du.PracticallyEquals(&want, EPSILON) {
+		t.Errorf("derivative in u failed: got %v, want %v", du, want)
+	}
+	if want := (vec3.T{0, 2, 0}); !dv.PracticallyEquals(&want, EPSILON) {
+		t.Errorf("derivative in v failed: got %v, want %v", dv, want)
+	}
+	if got := surface.Normal(0.3, 0.6); !got.PracticallyEquals(&vec3.UnitZ, EPSILON) {
+		t.Errorf("normal failed: got %v, want %v", got, vec3.UnitZ)
+	}
+}
+
+func TestNewSurfaceErrors(t *testing.T) {
+	if _, err := NewSurface(1, 1, nil, nil, nil, nil); err == nil {
+		t.Errorf("expected error for empty surface")
+	}
+	ragged := [][]vec3.T{{{0, 0, 0}, {0, 1, 0}}, {{1, 0, 0}}}
+	if _, err := NewSurface(1, 1, ragged, nil, nil, nil); err == nil {
+		t.Errorf("expected error for rows with different lengths")
+	}
+}
+
+func TestCylinder(t *testing.T) {
+	// Extrude an exact circle along z
+	center := vec3.T{0, 0, 0}
+	xAxis := vec3.T{2, 0, 0}
+	yAxis := vec3.T{0, 2, 0}
+	circle := Arc(&center, &xAxis, &yAxis, 0, 2*math.Pi)
+	surface := Surface{
+		DegreeU:       circle.Degree,
+		DegreeV:       1,
+		ControlPoints: make([][]vec4.T, len(circle.ControlPoints)),
+		KnotsU:        circle.Knots,
+		KnotsV:        []float64{0, 0, 1, 1},
+	}
+	for i, p := range circle.ControlPoints {
+		top := p
+		top[2] = 3 * p[3]
+		surface.ControlPoints[i] = []vec4.T{p, top}
+	}
+	if err := surface.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for u := float64(0); u <= 1; u += 1.0 / 16 {
+		for v := float64(0); v <= 1; v += 0.25 {
+			p := surface.Point(u, v)
+			radial := vec3.T{p[0], p[1], 0}
+			if math.Abs(radial.Length()-2) > EPSILON || math.Abs(p[2]-3*v) > EPSILON {
+				t.Errorf("point %v at (%f, %f) is not on the cylinder", p, u, v)
+			}
+			radial.Normalize()
+			n := surface.Normal(u, v)
+			if math.Abs(vec3.Dot(&n, &radial)) < 1-EPSILON {
+				t.Errorf("normal %v at (%f, %f) is not radial %v", n, u, v, radial)
+			}
+		}
+	}
+
+	// Compare partial derivatives with finite differences
+	const h = 0.001
+	_, du, dv := surface.Derivatives(0.3, 0.4)
+	p0 := surface.Point(0.3-h, 0.4)
+	p1 := surface.Point(0.3+h, 0.4)
+	fd := vec3.Sub(&p1, &p0)
+	fd.Scale(1 / (2 * h))
+	if !du.PracticallyEquals(&fd, 0.01) {
+		t.Errorf("derivative in u failed: got %v, differences %v", du, fd)
+	}
+	p0 = surface.Point(0.3, 0.4-h)
+	p1 = surface.Point(0.3, 0.4+h)
+	fd = vec3.Sub(&p1, &p0)
+	fd.Scale(1 / (2 * h))
+	if !dv.PracticallyEquals(&fd, 0.01) {
+		t.Errorf("derivative in v failed: got %v, differences %v", dv, fd)
+	}
+}
@@ -0,0 +1,170 @@
+package path2
+
+import "github.com/ungerik/go3d/float64/vec2"
+
+// arcLengthSegments is the number of segments of the arc length tables
+// that are used to find points at a distance on bezier splines.
+const arcLengthSegments = 32
+
+// Polyline is a flattened sub-path.
+type Polyline struct {
+	Points []vec2.T
+	// Closed is true if the sub-path was closed,
+	// then the last point connects to the first one.
+	Closed bool
+}
+
+// BoundingBox returns the tight axis aligned bounding box of the path.
+// Returns a zero rectangle for an empty path.
+func (path *T) BoundingBox() vec2.Rect {
+	var rect vec2.Rect
+	first := true
+	extend := func(r vec2.Rect) {
+		if first {
+			rect = r
+			first = false
+			return
+		}
+		rect.Min = vec2.Min(&rect.Min, &r.Min)
+		rect.Max = vec2.Max(&rect.Max, &r.Max)
+	}
+	var start vec2.T
+	for i := range path.Segments {
+		seg := &path.Segments[i]
+		end := seg.End()
+		switch seg.Command {
+		case Quad:
+			quad := seg.Quadratic(&start)
+			extend(quad.BoundingBox())
+		case Cubic:
+			bez := seg.Bezier(&start)
+			extend(bez.BoundingBox())
+		default:
+			if first && seg.Command != Move {
+				extend(vec2.Rect{Min: start, Max: start})
+			}
+			extend(vec2.Rect{Min: end, Max: end})
+		}
+		start = end
+	}
+	return rect
+}
+
+// Length returns the length of all segments of the path.
+func (path *T) Length() float64 {
+	var length float64
+	var start vec2.T
+	for i := range path.Segments {
+		seg := &path.Segments[i]
+		end := seg.End()
+		switch seg.Command {
+		case Line, ClosePath:
+			d := vec2.Sub(&end, &start)
+			length += d.Length()
+		case Quad, Cubic:
+			bez := seg.Bezier(&start)
+			length += bez.Length(1)
+		}
+		start = end
+	}
+	return length
+}
+
+// PointAtDistance returns the point at distance along the path from its start
+// and the unit length direction of the path at that point.
+// Move segments don't add to the distance.
+// distance is clamped to the range from 0 to Length().
+func (path *T) PointAtDistance(distance float64) (point, direction vec2.T) {
+	var start vec2.T
+	for i := range path.Segments {
+		seg := &path.Segments[i]
+		end := seg.End()
+		switch seg.Command {
+		case Move:
+			if direction.IsZero() {
+				// No segment with a length before
+				point = end
+			}
+		case Line, ClosePath:
+			d := vec2.Sub(&end, &start)
+			length := d.Length()
+			if length == 0 {
+				break
+			}
+			direction = d.Scaled(1 / length)
+			if distance <= length {
+				point = direction.Scaled(max(0, distance))
+				point.Add(&start)
+				return point, direction
+			}
+			distance -= length
+			point = end
+		default:
+			bez := seg.Bezier(&start)
+			table := bez.ArcLengthTable(arcLengthSegments)
+			length := table.Length()
+			if length == 0 {
+				break
+			}
+			t := float64(1)
+			if distance <= length {
+				t = table.Param(distance)
+			}
+			point = bez.Point(t)
+			direction = bez.Derivative(t)
+			if direction.IsZero() {
+				// Control point coincides with the end point
+				direction = vec2.Sub(&bez.P3, &bez.P0)
+			}
+			direction.Normalize()
+			if distance <= length {
+				return point, direction
+			}
+			distance -= length
+		}
+		start = end
+	}
+	return point, direction
+}
+
+// Flatten approximates every sub-path with a polyline and appends them to dst.
+// The distance between any point of the path and the polylines is at most tolerance.
+// Closed polylines don't repeat their first point at the end.
+func (path *T) Flatten(tolerance float64, dst []Polyline) []Polyline {
+	var start vec2.T
+	current := -1
+	for i := range path.Segments {
+		seg := &path.Segments[i]
+		end := seg.End()
+		if seg.Command == Move {
+			dst = append(dst, Polyline{Points: []vec2.T{end}})
+			current = len(dst) - 1
+			start = end
+			continue
+		}
+		if current < 0 || dst[current].Closed {
+			// Drawing without a move starts a new sub-path at the current point
+			dst = append(dst, Polyline{Points: []vec2.T{start}})
+			current = len(dst) - 1
+		}
+		line := &dst[current]
+		switch seg.Command {
+		case Line:
+			line.Points = append(line.Points, end)
+		case Quad:
+			quad := seg.Quadratic(&start)
+			// Flatten appends the start point again, so it replaces the last point
+			line.Points = quad.Flatten(tolerance, line.Points[:len(line.Points)-1])
+		case Cubic:
+			bez := seg.Bezier(&start)
+			line.Points = bez.Flatten(tolerance, line.Points[:len(line.Points)-1])
+		case ClosePath:
+			if n := len(line.Points); n > 1 && line.Points[n-1] == line.Points[0] {
+				line.Points = line.Points[:n-1]
+			}
+			line.Closed = true
+		}
+		start = end
+	}
+	return dst
+}
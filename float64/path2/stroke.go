@@ -0,0 +1,256 @@
+package path2
+
+import (
+	"math"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// Join is the shape of the outline at the corners between segments.
+type Join int
+
+const (
+	// MiterJoin extends the outer edges until they meet,
+	// or uses a BevelJoin if that point is further away than the miter limit.
+	MiterJoin Join = iota
+	// RoundJoin connects the outer edges with a circular arc.
+	RoundJoin
+	// BevelJoin connects the outer edges with a straight line.
+	BevelJoin
+)
+
+// Cap is the shape of the outline at the ends of open sub-paths.
+type Cap int
+
+const (
+	// ButtCap ends the outline at the end point.
+	ButtCap Cap = iota
+	// RoundCap adds a half circle around the end point.
+	RoundCap
+	// SquareCap extends the outline by half of the width beyond the end point.
+	SquareCap
+)
+
+// DefaultMiterLimit is used for a StrokeStyle without MiterLimit,
+// it is the same as the default of SVG.
+const DefaultMiterLimit = 4
+
+// StrokeStyle defines the outline of a stroked path.
+type StrokeStyle struct {
+	Width float64
+	Join  Join
+	Cap   Cap
+	// MiterLimit is the maximum ratio of the miter length to the width for MiterJoin.
+	// Zero means DefaultMiterLimit.
+	MiterLimit float64
+}
+
+// Stroke returns the outline of the path drawn with a pen of style as polygons.
+// The path is flattened with tolerance, which also limits the error of round joins and caps.
+// The polygons can overlap and must be filled with the nonzero winding rule:
+// open sub-paths result in one polygon, closed sub-paths in an outer
+// and an inner polygon with opposite orientation.
+// Sub-paths without length result in a dot for round and square caps.
+func (path *T) Stroke(style *StrokeStyle, tolerance float64) [][]vec2.T {
+	h := style.Width / 2
+	if h <= 0 {
+		return nil
+	}
+	o := offsetter{join: style.Join, miterLimit: style.MiterLimit, tolerance: tolerance}
+	var outlines [][]vec2.T
+	for _, line := range path.Flatten(tolerance, nil) {
+		points := withoutDuplicates(line.Points, line.Closed)
+		switch {
+		case len(points) == 1:
+			if style.Cap == ButtCap {
+				continue
+			}
+			p := points[0]
+			outline := []vec2.T{{p[0], p[1] + h}}
+			outline = o.cap(outline, &p, &vec2.UnitX, h, style.Cap)
+			outline = append(outline, vec2.T{p[0], p[1] - h})
+			outline = o.cap(outline, &p, &vec2.T{-1, 0}, h, style.Cap)
+			outlines = append(outlines, outline)
+
+		case line.Closed && len(points) > 2:
+			outlines = append(outlines,
+				o.offset(points, h, true, nil),
+				o.offset(reversed(points), h, true, nil),
+			)
+
+		default:
+			n := len(points)
+			outline := o.offset(points, h, false, nil)
+			end := vec2.Sub(&points[n-1], &points[n-2])
+			outline = o.cap(outline, &points[n-1], end.Normalize(), h, style.Cap)
+			outline = o.offset(reversed(points), h, false, outline)
+			start := vec2.Sub(&points[0], &points[1])
+			outline = o.cap(outline, &points[0], start.Normalize(), h, style.Cap)
+			outlines = append(outlines, outline)
+		}
+	}
+	return outlines
+}
+
+// Offset returns the flattened sub-paths of the path offset by distance,
+// to the left of the path direction for positive and to the right for negative distances.
+// join and miterLimit define the outer corners like for Stroke(),
+// tolerance is used for flattening and round joins.
+// The offset polylines can have loops where the distance is larger
+// than the radius of curvature of the path.
+// Sub-paths without length are omitted.
+func (path *T) Offset(distance float64, join Join, miterLimit, tolerance float64) []Polyline {
+	o := offsetter{join: join, miterLimit: miterLimit, tolerance: tolerance}
+	var result []Polyline
+	for _, line := range path.Flatten(tolerance, nil) {
+		points := withoutDuplicates(line.Points, line.Closed)
+		if len(points) < 2 {
+			continue
+		}
+		closed := line.Closed && len(points) > 2
+		result = append(result, Polyline{Points: o.offset(points, distance, closed, nil), Closed: closed})
+	}
+	return result
+}
+
+type offsetter struct {
+	join       Join
+	miterLimit float64
+	tolerance  float64
+}
+
+// offset appends the polyline offset by h along the left normals to dst.
+func (o *offsetter) offset(points []vec2.T, h float64, closed bool, dst []vec2.T) []vec2.T {
+	n := len(points)
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+	// Left unit normals and lengths of the segments
+	normals := make([]vec2.T, segments)
+	lengths := make([]float64, segments)
+	for i := range normals {
+		d := vec2.Sub(&points[(i+1)%n], &points[i])
+		lengths[i] = d.Length()
+		normals[i] = vec2.T{-d[1] / lengths[i], d[0] / lengths[i]}
+	}
+
+	if closed {
+		for i := range points {
+			prev := (i + segments - 1) % segments
+			dst = o.appendJoin(dst, &points[i], &normals[prev], &normals[i], h, min(lengths[prev], lengths[i]))
+		}
+		return dst
+	}
+	first := normals[0].Scaled(h)
+	dst = append(dst, *first.Add(&points[0]))
+	for i := 1; i < n-1; i++ {
+		dst = o.appendJoin(dst, &points[i], &normals[i-1], &normals[i], h, min(lengths[i-1], lengths[i]))
+	}
+	last := normals[segments-1].Scaled(h)
+	return append(dst, *last.Add(&points[n-1]))
+}
+
+// appendJoin appends the offset points at the corner p between two segments
+// with the normals n0 and n1, where length is the length of the shorter segment.
+func (o *offsetter) appendJoin(dst []vec2.T, p, n0, n1 *vec2.T, h, length float64) []vec2.T {
+	a := n0.Scaled(h)
+	a.Add(p)
+	b := n1.Scaled(h)
+	b.Add(p)
+	cross := vec2.Cross(n0, n1)
+	dot := vec2.Dot(n0, n1)
+	if dot > 0 && math.Abs(cross) < 1e-6 {
+		// Straight continuation
+		return append(dst, a)
+	}
+	// Distance of the intersection of the offset lines from the corner along the segments
+	overlap := math.Abs(h * cross / (1 + dot))
+	// Intersection of the offset lines
+	miter := vec2.Add(n0, n1)
+	miter.Scale(h / (1 + dot)).Add(p)
+
+	if cross*h > 0 {
+		// Inner corner, the offset lines intersect if the segments are long enough,
+		// else the corner point connects them for a correct nonzero winding
+		if dot > -1 && overlap <= length {
+			return append(dst, miter)
+		}
+		return append(dst, a, *p, b)
+	}
+
+	switch o.join {
+	case MiterJoin:
+		limit := o.miterLimit
+		if limit <= 0 {
+			limit = DefaultMiterLimit
+		}
+		// The ratio of the miter length to the width is sqrt(2 / (1 + dot))
+		if 1+dot > 0 && 2 <= limit*limit*(1+dot) {
+			return append(dst, miter)
+		}
+		return append(dst, a, b)
+	case RoundJoin:
+		dst = append(dst, a)
+		from := vec2.Sub(&a, p)
+		dst = o.appendArc(dst, p, &from, math.Atan2(cross, dot))
+		return append(dst, b)
+	default:
+		return append(dst, a, b)
+	}
+}
+
+// cap appends the cap at the end point p of a sub-path with the outward direction dir.
+// The outline arrives at the left side of the end and continues at the right side.
+func (o *offsetter) cap(dst []vec2.T, p, dir *vec2.T, h float64, capStyle Cap) []vec2.T {
+	left := vec2.T{-dir[1] * h, dir[0] * h}
+	switch capStyle {
+	case RoundCap:
+		dst = o.appendArc(dst, p, &left, -math.Pi)
+	case SquareCap:
+		forward := dir.Scaled(h)
+		a := vec2.Add(p, &left)
+		b := vec2.Sub(p, &left)
+		dst = append(dst, *a.Add(&forward), *b.Add(&forward))
+	}
+	return dst
+}
+
+// appendArc appends the points of a circular arc around center
+// starting at center+from and rotating by angle, without the start and end point.
+// The number of points keeps the distance of the chords from the arc below the tolerance.
+func (o *offsetter) appendArc(dst []vec2.T, center, from *vec2.T, angle float64) []vec2.T {
+	maxStep := float64(math.Pi / 2)
+	if r := from.Length(); o.tolerance < r {
+		maxStep = min(maxStep, 2*math.Acos(1-o.tolerance/r))
+	}
+	steps := int(math.Ceil(math.Abs(angle) / maxStep))
+	for i := 1; i < steps; i++ {
+		p := from.Rotated(angle * float64(i) / float64(steps))
+		dst = append(dst, *p.Add(center))
+	}
+	return dst
+}
+
+// withoutDuplicates returns the points without consecutive duplicates
+// and without a last point that equals the first for closed polylines.
+func withoutDuplicates(points []vec2.T, closed bool) []vec2.T {
+	result := make([]vec2.T, 0, len(points))
+	for i, p := range points {
+		if i == 0 || p != result[len(result)-1] {
+			result = append(result, p)
+		}
+	}
+	if n := len(result); closed && n > 1 && result[n-1] == result[0] {
+		result = result[:n-1]
+	}
+	return result
+}
+
+func reversed(points []vec2.T) []vec2.T {
+	result := make([]vec2.T, len(points))
+	for i, p := range points {
+		result[len(points)-1-i] = p
+	}
+	return result
+}
@@ -0,0 +1,146 @@
+package path2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// signedArea returns the sum of the signed areas of the polygons,
+// which is the area of their nonzero fill if they don't overlap.
+func signedArea(polygons [][]vec2.T) float64 {
+	var area float64
+	for _, polygon := range polygons {
+		for i := range polygon {
+			j := (i + 1) % len(polygon)
+			area += vec2.Cross(&polygon[i], &polygon[j]) / 2
+		}
+	}
+	return area
+}
+
+func TestStrokeLine(t *testing.T) {
+	line := mustParse(t, "M0 0 L10 0")
+	for _, test := range []struct {
+		cap  Cap
+		area float64
+	}{
+		{ButtCap, 20},
+		{SquareCap, 24},
+		{RoundCap, 20 + math.Pi},
+	} {
+		outlines := line.Stroke(&StrokeStyle{Width: 2, Cap: test.cap}, 0.001)
+		if len(outlines) != 1 {
+			t.Fatalf("expected one outline, got %v", outlines)
+		}
+		if area := signedArea(outlines); math.Abs(math.Abs(area)-test.area) > 0.01 {
+			t.Errorf("area of stroke with cap %d failed: got %f, want %f", test.cap, area, test.area)
+		}
+		for _, p := range outlines[0] {
+			if p[1] < -1-EPSILON || p[1] > 1+EPSILON || p[0] < -1-EPSILON || p[0] > 11+EPSILON {
+				t.Errorf("point %v of stroke with cap %d is outside of the pen", p, test.cap)
+			}
+		}
+	}
+
+	dot := mustParse(t, "M5 5")
+	if outlines := dot.Stroke(&StrokeStyle{Width: 2, Cap: RoundCap}, 0.001); math.Abs(math.Abs(signedArea(outlines))-math.Pi) > 0.01 {
+		t.Errorf("round dot should be a circle, got %v", outlines)
+	}
+	if outlines := dot.Stroke(&StrokeStyle{Width: 2, Cap: ButtCap}, 0.001); len(outlines) != 0 {
+		t.Errorf("dot with butt cap should be omitted, got %v", outlines)
+	}
+	if outlines := line.Stroke(&StrokeStyle{Width: 0}, 0.001); len(outlines) != 0 {
+		t.Errorf("stroke without width should be empty, got %v", outlines)
+	}
+}
+
+func TestStrokeJoins(t *testing.T) {
+	square := mustParse(t, "M0 0 H10 V10 H0 Z")
+	for _, test := range []struct {
+		join Join
+		area float64
+	}{
+		{MiterJoin, 144 - 64},
+		{BevelJoin, 144 - 64 - 4*0.5},
+		{RoundJoin, 144 - 64 - 4*(1-math.Pi/4)},
+	} {
+		outlines := square.Stroke(&StrokeStyle{Width: 2, Join: test.join}, 0.001)
+		if len(outlines) != 2 {
+			t.Fatalf("expected outer and inner outline, got %v", outlines)
+		}
+		if a0, a1 := signedArea(outlines[:1]), signedArea(outlines[1:]); a0*a1 >= 0 {
+			t.Errorf("outlines with join %d should have opposite orientation: %f, %f", test.join, a0, a1)
+		}
+		if area := signedArea(outlines); math.Abs(math.Abs(area)-test.area) > 0.01 {
+			t.Errorf("area of stroke with join %d failed: got %f, want %f", test.join, area, test.area)
+		}
+	}
+
+	// A sharp corner exceeds the miter limit and is beveled
+	sharp := mustParse(t, "M0 0 L10 1 L0 2")
+	corner := vec2.T{10, 1}
+	for _, limit := range []float64{0, 2, 100} {
+		outlines := sharp.Stroke(&StrokeStyle{Width: 2, MiterLimit: limit}, 0.001)
+		var furthest float64
+		for _, p := range outlines[0] {
+			// Ignore the caps at the start and end
+			if p[0] > 5 {
+				d := vec2.Sub(&p, &corner)
+				furthest = max(furthest, d.Length())
+			}
+		}
+		if limit == 0 {
+			limit = DefaultMiterLimit
+		}
+		if miter := math.Hypot(10, 1); limit > miter && math.Abs(furthest-miter) > 0.01 {
+			t.Errorf("miter should reach %f, got %f", miter, furthest)
+		} else if limit < miter && furthest > 1+EPSILON {
+			t.Errorf("corner with miter limit %f should be beveled, furthest point is at %f", limit, furthest)
+		}
+	}
+}
+
+func TestOffset(t *testing.T) {
+	square := mustParse(t, "M0 0 H10 V10 H0 Z")
+	inner := square.Offset(1, MiterJoin, 0, 0.001)
+	if len(inner) != 1 || !inner[0].Closed || len(inner[0].Points) != 4 {
+		t.Fatalf("expected closed offset square, got %v", inner)
+	}
+	if area := signedArea([][]vec2.T{inner[0].Points}); math.Abs(area-64) > EPSILON {
+		t.Errorf("inner offset area failed: got %f, want 64", area)
+	}
+	outer := square.Offset(-1, MiterJoin, 0, 0.001)
+	if area := signedArea([][]vec2.T{outer[0].Points}); math.Abs(area-144) > EPSILON {
+		t.Errorf("outer offset area failed: got %f, want 144", area)
+	}
+
+	// The offset of a circle is a circle
+	circle := mustParse(t, "M10 0 A10 10 0 0 1 -10 0 A10 10 0 0 1 10 0 Z")
+	for _, distance := range []float64{2, -2} {
+		lines := circle.Offset(distance, RoundJoin, 0, 0.001)
+		for _, p := range lines[0].Points {
+			if r := p.Length(); math.Abs(r-10+distance) > 0.01 {
+				t.Errorf("offset %f of circle has point %v at radius %f", distance, p, r)
+			}
+		}
+	}
+
+	open := mustParse(t, "M0 0 L10 0 L10 10")
+	lines := open.Offset(-1, RoundJoin, 0, 0.001)
+	if len(lines) != 1 || lines[0].Closed {
+		t.Fatalf("expected an open polyline, got %v", lines)
+	}
+	points := lines[0].Points
+	if points[0] != (vec2.T{0, -1}) || points[len(points)-1] != (vec2.T{11, 10}) {
+		t.Errorf("open offset failed: %v", points)
+	}
+	for _, p := range points {
+		if p[0] > 10 && p[1] < 0 {
+			if d := vec2.Sub(&p, &vec2.T{10, 0}); math.Abs(d.Length()-1) > EPSILON {
+				t.Errorf("round join point %v is not on the circle around the corner", p)
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package polygon2
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+func rect(x0, y0, x1, y1 float64) T {
+	return T{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}}
+}
+
+// totalArea returns the sum of the signed areas of the rings,
+// which is the covered area for left winding outlines and right winding holes.
+func totalArea(rings [][]vec2.T) float64 {
+	var area float64
+	for _, ring := range rings {
+		r := T(ring)
+		area += r.Area()
+	}
+	return area
+}
+
+func TestBoolean(t *testing.T) {
+	a := []T{rect(0, 0, 2, 2)}
+	right := rect(1, 1, 3, 3)
+	b := []T{right.Reversed()}
+	for op, want := range map[Operation]float64{Union: 7, Intersection: 1, Difference: 3, Xor: 6} {
+		result := Boolean(a, b, op)
+		if area := totalArea(result); abs(area-want) > EPSILON {
+			t.Errorf("operation %d failed: got area %f, want %f", op, area, want)
+		}
+	}
+	union := Boolean(a, b, Union)
+	if len(union) != 1 || len(union[0]) != 8 {
+		t.Errorf("Union failed: got %v", union)
+	}
+	xor := Boolean(a, b, Xor)
+	if len(xor) != 2 {
+		t.Errorf("Xor failed: got %d rings, want 2", len(xor))
+	}
+	for _, ring := range xor {
+		if r := T(ring); !r.IsLeftWinding() {
+			t.Errorf("Xor ring %v is not left winding", ring)
+		}
+	}
+}
+
+func TestBooleanHoles(t *testing.T) {
+	// Windings of outline and hole don't matter
+	a := []T{rect(0, 0, 4, 4), rect(1, 1, 3, 3)}
+	b := []T{rect(2, 2, 5, 5)}
+	for op, want := range map[Operation]float64{Union: 18, Intersection: 3, Difference: 9, Xor: 15} {
+		result := Boolean(a, b, op)
+		if area := totalArea(result); abs(area-want) > EPSILON {
+			t.Errorf("operation %d failed: got area %f, want %f", op, area, want)
+		}
+	}
+	union := Boolean(a, b, Union)
+	holes := 0
+	for _, ring := range union {
+		if r := T(ring); r.IsRightWinding() {
+			holes++
+			// The hole is the part of the hole of a that is not covered by b
+			if area := -r.Area(); abs(area-3) > EPSILON {
+				t.Errorf("hole failed: got area %f, want 3", area)
+			}
+		}
+	}
+	if len(union) != 2 || holes != 1 {
+		t.Errorf("Union failed: got %v", union)
+	}
+}
+
+func TestBooleanDegenerated(t *testing.T) {
+	// Squares with a shared edge
+	a := []T{rect(0, 0, 1, 1)}
+	b := []T{rect(1, 0, 2, 1)}
+	union := Boolean(a, b, Union)
+	if len(union) != 1 || len(union[0]) != 4 || abs(totalArea(union)-2) > EPSILON {
+		t.Errorf("Union of shared edge failed: got %v", union)
+	}
+	if result := Boolean(a, b, Intersection); len(result) != 0 {
+		t.Errorf("Intersection of shared edge failed: got %v", result)
+	}
+	if result := Boolean(a, b, Difference); len(result) != 1 || abs(totalArea(result)-1) > EPSILON {
+		t.Errorf("Difference of shared edge failed: got %v", result)
+	}
+
+	// Identical polygons
+	if result := Boolean(a, a, Intersection); len(result) != 1 || abs(totalArea(result)-1) > EPSILON {
+		t.Errorf("Intersection of identical polygons failed: got %v", result)
+	}
+	if result := Boolean(a, a, Difference); len(result) != 0 {
+		t.Errorf("Difference of identical polygons failed: got %v", result)
+	}
+
+	// Squares touching in a corner result in separate rings
+	c := []T{rect(1, 1, 2, 2)}
+	union = Boolean(a, c, Union)
+	if len(union) != 2 || abs(totalArea(union)-2) > EPSILON {
+		t.Errorf("Union of touching corners failed: got %v", union)
+	}
+
+	// Partially overlapping edges
+	d := []T{rect(0.5, 1, 1.5, 2)}
+	union = Boolean(a, d, Union)
+	if len(union) != 1 || len(union[0]) != 8 || abs(totalArea(union)-2) > EPSILON {
+		t.Errorf("Union of overlapping edges failed: got %v", union)
+	}
+
+	if result := Boolean(a, nil, Union); len(result) != 1 || abs(totalArea(result)-1) > EPSILON {
+		t.Errorf("Union with nothing failed: got %v", result)
+	}
+}
+
+func TestBooleanRounding(t *testing.T) {
+	// Triangles touching in a vertex with nearly opposite edges,
+	// where the intersection parameters at the vertex are not exactly 0 or 1
+	a := T{{1.282, 0.732}, {-0.891, 0.565}, {0.284, -1.402}}
+	b := T{{1.282, 0.732}, {3.455, 0.894}, {2.285, 2.864}}
+	areaA, areaB := a.Area(), b.Area()
+	for op, want := range map[Operation]float64{Union: areaA + areaB, Intersection: 0, Difference: areaA, Xor: areaA + areaB} {
+		if area := totalArea(Boolean([]T{a}, []T{b}, op)); abs(area-want) > EPSILON {
+			t.Errorf("operation %d of touching triangles failed: got area %f, want %f", op, area, want)
+		}
+	}
+}
+
+func TestClippedToRect(t *testing.T) {
+	triangle := T{{0, 0}, {2, 0}, {0, 2}}
+	r := vec2.Rect{Min: vec2.T{-1, 0}, Max: vec2.T{2, 1}}
+	clipped := triangle.ClippedToRect(&r)
+	// Trapezoid of the triangle below y = 1
+	if area := clipped.Area(); abs(area-1.5) > EPSILON {
+		t.Errorf("ClippedToRect failed: got area %f, want 1.5 for %v", area, clipped)
+	}
+	for _, p := range clipped {
+		if !r.ContainsPoint(&p) {
+			t.Errorf("ClippedToRect failed: %v is outside of %v", p, r)
+		}
+	}
+	if outside := (T{{5, 5}, {6, 5}, {6, 6}}); len(outside.ClippedToRect(&r)) != 0 {
+		t.Errorf("ClippedToRect of outside polygon failed")
+	}
+}
+
+func TestClippedToConvex(t *testing.T) {
+	clip := T{{0, 0}, {2, 2}, {4, 0}} // Right winding triangle
+	clipped := lShape.ClippedToConvex(&clip)
+	// Trapezoid of the triangle below y = 1, the upper part of the L-shape is outside
+	if area := clipped.Area(); abs(area-3) > EPSILON {
+		t.Errorf("ClippedToConvex failed: got area %f, want 3 for %v", area, clipped)
+	}
+	if !clipped.IsLeftWinding() {
+		t.Errorf("ClippedToConvex failed: winding of %v changed", clipped)
+	}
+}
@@ -0,0 +1,65 @@
+package polygon2
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+func TestConvexHull(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	// Corners of a square with points on its edges and inside of it
+	points := []vec2.T{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {2, 0}, {4, 2}, {0, 0}, {4, 4}}
+	for i := 0; i < 100; i++ {
+		points = append(points, vec2.T{rnd.Float64() * 4, rnd.Float64() * 4})
+	}
+	hull := ConvexHull(points)
+	want := T{{0, 0}, {4, 0}, {4, 4}, {0, 4}}
+	if len(hull) != len(want) {
+		t.Fatalf("ConvexHull failed: got %v, want %v", hull, want)
+	}
+	for i := range want {
+		if hull[i] != want[i] {
+			t.Errorf("point %d failed: got %v, want %v", i, hull[i], want[i])
+		}
+	}
+
+	circle := make([]vec2.T, 50)
+	for i := range circle {
+		circle[i] = vec2.UnitX.Rotated(float64(i) * 0.3)
+	}
+	hull = ConvexHull(circle)
+	if !hull.IsConvex() || !hull.IsLeftWinding() {
+		t.Errorf("ConvexHull of circle is not convex and left winding: %v", hull)
+	}
+	for i := range circle {
+		if hull.WindingNumber(&circle[i]) == 0 && !containsVertex(hull, &circle[i]) {
+			t.Errorf("ConvexHull doesn't contain point %v", circle[i])
+		}
+	}
+}
+
+func TestConvexHullDegenerated(t *testing.T) {
+	if hull := ConvexHull(nil); len(hull) != 0 {
+		t.Errorf("no points failed: got %v", hull)
+	}
+	if hull := ConvexHull([]vec2.T{{1, 2}, {1, 2}}); len(hull) != 1 || hull[0] != (vec2.T{1, 2}) {
+		t.Errorf("equal points failed: got %v", hull)
+	}
+	if hull := ConvexHull([]vec2.T{{1, 0}, {3, 0}, {2, 0}}); len(hull) != 2 || hull[0] != (vec2.T{1, 0}) || hull[1] != (vec2.T{3, 0}) {
+		t.Errorf("horizontal points failed: got %v", hull)
+	}
+	if hull := ConvexHull([]vec2.T{{2, 2}, {0, 0}, {1, 1}, {3, 3}}); len(hull) != 2 || hull[0] != (vec2.T{0, 0}) || hull[1] != (vec2.T{3, 3}) {
+		t.Errorf("diagonal points failed: got %v", hull)
+	}
+}
+
+func containsVertex(poly T, p *vec2.T) bool {
+	for i := range poly {
+		if poly[i] == *p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package polygon2
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// checkTriangles checks that all triangles are left winding,
+// have valid indices and cover wantArea.
+func checkTriangles(t *testing.T, name string, points []vec2.T, triangles [][3]int, wantArea float64) {
+	t.Helper()
+	var area float64
+	for _, tri := range triangles {
+		for _, i := range tri {
+			if i < 0 || i >= len(points) {
+				t.Fatalf("%s failed: invalid index %d in %v", name, i, tri)
+			}
+		}
+		a := orient(&points[tri[0]], &points[tri[1]], &points[tri[2]]) / 2
+		if a <= 0 {
+			t.Errorf("%s failed: triangle %v is not left winding", name, tri)
+		}
+		area += a
+	}
+	if abs(area-wantArea) > EPSILON {
+		t.Errorf("%s failed: got area %f, want %f", name, area, wantArea)
+	}
+}
+
+func allPoints(outline T, holes []T) []vec2.T {
+	points := append([]vec2.T(nil), outline...)
+	for _, hole := range holes {
+		points = append(points, hole...)
+	}
+	return points
+}
+
+func TestTriangulate(t *testing.T) {
+	triangles := Triangulate(lShape, nil)
+	if len(triangles) != len(lShape)-2 {
+		t.Errorf("number of triangles failed: got %d, want %d", len(triangles), len(lShape)-2)
+	}
+	checkTriangles(t, "L-shape", lShape, triangles, 6)
+
+	reversed := lShape.Reversed()
+	checkTriangles(t, "reversed L-shape", reversed, Triangulate(reversed, nil), 6)
+
+	if triangles := Triangulate(T{{0, 0}, {1, 1}}, nil); len(triangles) != 0 {
+		t.Errorf("too few points failed: got %v", triangles)
+	}
+}
+
+func TestTriangulateHoles(t *testing.T) {
+	outline := T{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	holes := []T{
+		{{2, 2}, {2, 4}, {4, 4}, {4, 2}},
+		// Left winding holes are reversed
+		{{6, 6}, {8, 6}, {8, 8}, {6, 8}},
+		{{6, 2}, {8, 2}, {7, 4}},
+	}
+	points := allPoints(outline, holes)
+	triangles := Triangulate(outline, holes)
+	checkTriangles(t, "holes", points, triangles, 100-4-4-2)
+
+	// No triangle may contain the center of a hole
+	for _, center := range []vec2.T{{3, 3}, {7, 7}, {7, 3}} {
+		for _, tri := range triangles {
+			if pointInTriangle(&center, &points[tri[0]], &points[tri[1]], &points[tri[2]]) {
+				t.Errorf("triangle %v contains hole center %v", tri, center)
+			}
+		}
+	}
+}
+
+func TestTriangulateHoleBridges(t *testing.T) {
+	// Bridges to points below the ray of a hole must not cross holes merged before
+	outline := T{{0, 0}, {20, 0}, {20, 20}, {0, 20}}
+	holes := []T{
+		{{3, 11}, {4.5, 10}, {3, 12}, {2.5, 10.5}},
+		{{14, 6}, {14.5, 5}, {14, 7}, {13.5, 5}},
+		{{10, 6}, {10.5, 5}, {10, 7.5}, {8.5, 5}},
+	}
+	area := outline.Area()
+	for _, hole := range holes {
+		area -= hole.Area()
+	}
+	points := allPoints(outline, holes)
+	checkTriangles(t, "hole bridges", points, Triangulate(outline, holes), area)
+	checkTriangles(t, "hole bridges Delaunay", points, TriangulateDelaunay(outline, holes), area)
+}
+
+func TestTriangulateDegenerated(t *testing.T) {
+	// Duplicate and collinear points
+	outline := T{{0, 0}, {1, 0}, {2, 0}, {2, 0}, {4, 0}, {4, 2}, {2, 2}, {0, 2}, {0, 1}}
+	checkTriangles(t, "collinear", outline, Triangulate(outline, nil), 8)
+	checkTriangles(t, "collinear Delaunay", outline, TriangulateDelaunay(outline, nil), 8)
+
+	// No area
+	line := T{{0, 0}, {1, 1}, {2, 2}}
+	checkTriangles(t, "line", line, Triangulate(line, nil), 0)
+}
+
+func TestTriangulateDelaunay(t *testing.T) {
+	// A thin fan that ear clipping splits into sliver triangles
+	outline := T{{0, 0}, {1, -0.2}, {2, -0.3}, {3, -0.3}, {4, -0.2}, {5, 0}, {5, 1.5}, {0, 1}}
+	area := outline.Area()
+	triangles := TriangulateDelaunay(outline, nil)
+	checkTriangles(t, "Delaunay", outline, triangles, area)
+
+	// No point may be inside of the circumcircle of a triangle it sees,
+	// all points of this convex polygon see each other
+	for _, tri := range triangles {
+		for i := range outline {
+			if i == tri[0] || i == tri[1] || i == tri[2] {
+				continue
+			}
+			if inCircle(&outline[tri[0]], &outline[tri[1]], &outline[tri[2]], &outline[i]) {
+				t.Errorf("point %d is inside of the circumcircle of %v", i, tri)
+			}
+		}
+	}
+
+	// Constrained edges of holes are kept
+	holes := []T{{{2, 2}, {3, 2}, {3, 3}, {2, 3}}}
+	square := T{{0, 0}, {5, 0}, {5, 5}, {0, 5}}
+	points := allPoints(square, holes)
+	triangles = TriangulateDelaunay(square, holes)
+	checkTriangles(t, "Delaunay holes", points, triangles, 24)
+	edges := make(map[[2]int]bool)
+	for _, tri := range triangles {
+		for i := range tri {
+			a, b := tri[i], tri[(i+1)%3]
+			edges[[2]int{min(a, b), max(a, b)}] = true
+		}
+	}
+	for i := 0; i < 4; i++ {
+		if a, b := 4+i, 4+(i+1)%4; !edges[[2]int{min(a, b), max(a, b)}] {
+			t.Errorf("hole edge %d-%d is missing", a, b)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package predicates
+
+import "math"
+
+// An expansion is a sum of float64 components without overlapping bits
+// in the order of increasing magnitude, which represents a number exactly.
+// Zero components are eliminated, the empty expansion is zero.
+// See: Jonathan Richard Shewchuk, Adaptive Precision Floating-Point Arithmetic
+// and Fast Robust Geometric Predicates, 1997
+
+// twoSum returns the sum of a and b and its rounding error.
+func twoSum(a, b float64) (x, y float64) {
+	x = a + b
+	bv := x - a
+	av := x - bv
+	return x, (a - av) + (b - bv)
+}
+
+// fastTwoSum returns the sum of a and b and its rounding error for |a| >= |b|.
+func fastTwoSum(a, b float64) (x, y float64) {
+	x = a + b
+	return x, b - (x - a)
+}
+
+// twoDiff returns the difference of a and b and its rounding error.
+func twoDiff(a, b float64) (x, y float64) {
+	x = a - b
+	bv := a - x
+	av := x + bv
+	return x, (a - av) + (bv - b)
+}
+
+// twoProduct returns the product of a and b and its rounding error.
+func twoProduct(a, b float64) (x, y float64) {
+	x = a * b
+	return x, math.FMA(a, b, -x)
+}
+
+// diff returns the exact difference of a and b as expansion.
+func diff(a, b float64) []float64 {
+	x, y := twoDiff(a, b)
+	e := make([]float64, 0, 2)
+	if y != 0 {
+		e = append(e, y)
+	}
+	if x != 0 {
+		e = append(e, x)
+	}
+	return e
+}
+
+// grow returns the sum of the expansion e and b.
+func grow(e []float64, b float64) []float64 {
+	h := make([]float64, 0, len(e)+1)
+	q := b
+	for _, c := range e {
+		var hh float64
+		q, hh = twoSum(q, c)
+		if hh != 0 {
+			h = append(h, hh)
+		}
+	}
+	if q != 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// add returns the sum of the expansions e and f.
+func add(e, f []float64) []float64 {
+	for _, c := range f {
+		e = grow(e, c)
+	}
+	return e
+}
+
+// negate returns the negative of the expansion e.
+func negate(e []float64) []float64 {
+	n := make([]float64, len(e))
+	for i, c := range e {
+		n[i] = -c
+	}
+	return n
+}
+
+// sub returns the difference of the expansions e and f.
+func sub(e, f []float64) []float64 {
+	return add(e, negate(f))
+}
+
+// scale returns the product of the expansion e and b.
+func scale(e []float64, b float64) []float64 {
+	if len(e) == 0 || b == 0 {
+		return nil
+	}
+	h := make([]float64, 0, 2*len(e))
+	q, hh := twoProduct(e[0], b)
+	if hh != 0 {
+		h = append(h, hh)
+	}
+	for _, c := range e[1:] {
+		p1, p0 := twoProduct(c, b)
+		var sum float64
+		sum, hh = twoSum(q, p0)
+		if hh != 0 {
+			h = append(h, hh)
+		}
+		q, hh = fastTwoSum(p1, sum)
+		if hh != 0 {
+			h = append(h, hh)
+		}
+	}
+	if q != 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// mul returns the product of the expansions e and f.
+func mul(e, f []float64) []float64 {
+	var product []float64
+	for _, c := range f {
+		product = add(product, scale(e, c))
+	}
+	return product
+}
+
+// mostSignificant returns the largest component of the expansion e,
+// which has the sign of e, or zero for the empty expansion.
+func mostSignificant(e []float64) float64 {
+	if len(e) == 0 {
+		return 0
+	}
+	return e[len(e)-1]
+}
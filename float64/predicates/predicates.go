@@ -0,0 +1,178 @@
+// Package predicates contains robust geometric predicates for float64 vectors
+// that return the exact sign of their determinant.
+// The determinant is first calculated with float64 and an error bound,
+// only if the sign is uncertain it is calculated again with exact arithmetic.
+// See: Jonathan Richard Shewchuk, Adaptive Precision Floating-Point Arithmetic
+// and Fast Robust Geometric Predicates, 1997, https://www.cs.cmu.edu/~quake/robust.html
+package predicates
+
+import (
+	"math"
+
+	"github.com/ungerik/go3d/float64/vec2"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// epsilon is half of the relative rounding error of float64.
+const epsilon = 0x1p-53
+
+// Error bounds of the float64 determinants relative to their permanents.
+const (
+	orient2dErrorBound = (3 + 16*epsilon) * epsilon
+	orient3dErrorBound = (7 + 56*epsilon) * epsilon
+	inCircleErrorBound = (10 + 96*epsilon) * epsilon
+	inSphereErrorBound = (16 + 224*epsilon) * epsilon
+)
+
+// Orient2D returns a positive value if a, b and c are left winding (counter clockwise),
+// a negative value if they are right winding and zero if they are collinear.
+// The result approximates twice the signed area of the triangle a, b, c.
+func Orient2D(a, b, c *vec2.T) float64 {
+	detLeft := (a[0] - c[0]) * (b[1] - c[1])
+	detRight := (a[1] - c[1]) * (b[0] - c[0])
+	det := detLeft - detRight
+	var detSum float64
+	switch {
+	case detLeft > 0:
+		if detRight <= 0 {
+			return det
+		}
+		detSum = detLeft + detRight
+	case detLeft < 0:
+		if detRight >= 0 {
+			return det
+		}
+		detSum = -detLeft - detRight
+	default:
+		return det
+	}
+	if bound := orient2dErrorBound * detSum; det >= bound || -det >= bound {
+		return det
+	}
+
+	acx, acy := diff(a[0], c[0]), diff(a[1], c[1])
+	bcx, bcy := diff(b[0], c[0]), diff(b[1], c[1])
+	return mostSignificant(sub(mul(acx, bcy), mul(acy, bcx)))
+}
+
+// Orient3D returns a positive value if d is below the plane through a, b and c,
+// where below is the side from which a, b and c appear right winding (clockwise),
+// a negative value if d is above the plane and zero if the points are coplanar.
+// The result approximates six times the signed volume of the tetrahedron a, b, c, d.
+func Orient3D(a, b, c, d *vec3.T) float64 {
+	adx, ady, adz := a[0]-d[0], a[1]-d[1], a[2]-d[2]
+	bdx, bdy, bdz := b[0]-d[0], b[1]-d[1], b[2]-d[2]
+	cdx, cdy, cdz := c[0]-d[0], c[1]-d[1], c[2]-d[2]
+
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	det := adz*(bdxcdy-cdxbdy) + bdz*(cdxady-adxcdy) + cdz*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*math.Abs(adz) +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*math.Abs(bdz) +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*math.Abs(cdz)
+	if bound := orient3dErrorBound * permanent; det > bound || -det > bound {
+		return det
+	}
+
+	ex, ey, ez := diff(a[0], d[0]), diff(a[1], d[1]), diff(a[2], d[2])
+	fx, fy, fz := diff(b[0], d[0]), diff(b[1], d[1]), diff(b[2], d[2])
+	gx, gy, gz := diff(c[0], d[0]), diff(c[1], d[1]), diff(c[2], d[2])
+	return mostSignificant(determinant3(ex, ey, ez, fx, fy, fz, gx, gy, gz))
+}
+
+// InCircle returns a positive value if d is inside of the circle through a, b and c,
+// a negative value if it is outside and zero if the points are cocircular.
+// a, b and c must be left winding (counter clockwise), otherwise the sign is reversed.
+func InCircle(a, b, c, d *vec2.T) float64 {
+	adx, ady := a[0]-d[0], a[1]-d[1]
+	bdx, bdy := b[0]-d[0], b[1]-d[1]
+	cdx, cdy := c[0]-d[0], c[1]-d[1]
+
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	aLift := adx*adx + ady*ady
+	bLift := bdx*bdx + bdy*bdy
+	cLift := cdx*cdx + cdy*cdy
+	det := aLift*(bdxcdy-cdxbdy) + bLift*(cdxady-adxcdy) + cLift*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*aLift +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*bLift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*cLift
+	if bound := inCircleErrorBound * permanent; det > bound || -det > bound {
+		return det
+	}
+
+	ex, ey := diff(a[0], d[0]), diff(a[1], d[1])
+	fx, fy := diff(b[0], d[0]), diff(b[1], d[1])
+	gx, gy := diff(c[0], d[0]), diff(c[1], d[1])
+	eLift := add(mul(ex, ex), mul(ey, ey))
+	fLift := add(mul(fx, fx), mul(fy, fy))
+	gLift := add(mul(gx, gx), mul(gy, gy))
+	return mostSignificant(determinant3(ex, ey, eLift, fx, fy, fLift, gx, gy, gLift))
+}
+
+// InSphere returns a positive value if e is inside of the sphere through a, b, c and d,
+// a negative value if it is outside and zero if the points are cospherical.
+// Orient3D(a, b, c, d) must be positive, otherwise the sign is reversed.
+func InSphere(a, b, c, d, e *vec3.T) float64 {
+	aex, aey, aez := a[0]-e[0], a[1]-e[1], a[2]-e[2]
+	bex, bey, bez := b[0]-e[0], b[1]-e[1], b[2]-e[2]
+	cex, cey, cez := c[0]-e[0], c[1]-e[1], c[2]-e[2]
+	dex, dey, dez := d[0]-e[0], d[1]-e[1], d[2]-e[2]
+
+	aexbey, bexaey := aex*bey, bex*aey
+	bexcey, cexbey := bex*cey, cex*bey
+	cexdey, dexcey := cex*dey, dex*cey
+	dexaey, aexdey := dex*aey, aex*dey
+	aexcey, cexaey := aex*cey, cex*aey
+	bexdey, dexbey := bex*dey, dex*bey
+	ab, bc, cd, da := aexbey-bexaey, bexcey-cexbey, cexdey-dexcey, dexaey-aexdey
+	ac, bd := aexcey-cexaey, bexdey-dexbey
+
+	abc := aez*bc - bez*ac + cez*ab
+	bcd := bez*cd - cez*bd + dez*bc
+	cda := cez*da + dez*ac + aez*cd
+	dab := dez*ab + aez*bd + bez*da
+	aLift := aex*aex + aey*aey + aez*aez
+	bLift := bex*bex + bey*bey + bez*bez
+	cLift := cex*cex + cey*cey + cez*cez
+	dLift := dex*dex + dey*dey + dez*dez
+	det := (dLift*abc - cLift*dab) + (bLift*cda - aLift*bcd)
+
+	abP := math.Abs(aexbey) + math.Abs(bexaey)
+	bcP := math.Abs(bexcey) + math.Abs(cexbey)
+	cdP := math.Abs(cexdey) + math.Abs(dexcey)
+	daP := math.Abs(dexaey) + math.Abs(aexdey)
+	acP := math.Abs(aexcey) + math.Abs(cexaey)
+	bdP := math.Abs(bexdey) + math.Abs(dexbey)
+	permanent := (math.Abs(aez)*bcP+math.Abs(bez)*acP+math.Abs(cez)*abP)*dLift +
+		(math.Abs(dez)*abP+math.Abs(aez)*bdP+math.Abs(bez)*daP)*cLift +
+		(math.Abs(cez)*daP+math.Abs(dez)*acP+math.Abs(aez)*cdP)*bLift +
+		(math.Abs(bez)*cdP+math.Abs(cez)*bdP+math.Abs(dez)*bcP)*aLift
+	if bound := inSphereErrorBound * permanent; det > bound || -det > bound {
+		return det
+	}
+
+	var x, y, z, lift [4][]float64
+	for i, p := range [4]*vec3.T{a, b, c, d} {
+		x[i], y[i], z[i] = diff(p[0], e[0]), diff(p[1], e[1]), diff(p[2], e[2])
+		lift[i] = add(add(mul(x[i], x[i]), mul(y[i], y[i])), mul(z[i], z[i]))
+	}
+	// Cofactor expansion along the lift column
+	det3 := func(i, j, k int) []float64 {
+		return determinant3(x[i], y[i], z[i], x[j], y[j], z[j], x[k], y[k], z[k])
+	}
+	exact := sub(mul(lift[3], det3(0, 1, 2)), mul(lift[2], det3(0, 1, 3)))
+	exact = add(exact, sub(mul(lift[1], det3(0, 2, 3)), mul(lift[0], det3(1, 2, 3))))
+	return mostSignificant(exact)
+}
+
+// determinant3 returns the exact determinant of the 3x3 matrix of expansions with the rows
+// (ax, ay, az), (bx, by, bz) and (cx, cy, cz).
+func determinant3(ax, ay, az, bx, by, bz, cx, cy, cz []float64) []float64 {
+	bc := sub(mul(bx, cy), mul(cx, by))
+	ca := sub(mul(cx, ay), mul(ax, cy))
+	ab := sub(mul(ax, by), mul(bx, ay))
+	return add(add(mul(az, bc), mul(bz, ca)), mul(cz, ab))
+}
@@ -0,0 +1,174 @@
+package predicates
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec2"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// exactSign returns the sign of the determinant of the matrix with the rows
+// of the differences of the points to the last point, calculated with big.Rat.
+// If lifted is true, the squared lengths of the differences are appended as last column.
+func exactSign(points [][]float64, lifted bool) int {
+	last := points[len(points)-1]
+	var m [][]*big.Rat
+	for _, p := range points[:len(points)-1] {
+		var row []*big.Rat
+		lift := new(big.Rat)
+		for i := range p {
+			d := new(big.Rat).SetFloat64(p[i])
+			d.Sub(d, new(big.Rat).SetFloat64(last[i]))
+			row = append(row, d)
+			lift.Add(lift, new(big.Rat).Mul(d, d))
+		}
+		if lifted {
+			row = append(row, lift)
+		}
+		m = append(m, row)
+	}
+	return determinant(m).Sign()
+}
+
+func determinant(m [][]*big.Rat) *big.Rat {
+	if len(m) == 1 {
+		return m[0][0]
+	}
+	det := new(big.Rat)
+	for col := range m {
+		var minor [][]*big.Rat
+		for _, row := range m[1:] {
+			minor = append(minor, append(append([]*big.Rat(nil), row[:col]...), row[col+1:]...))
+		}
+		term := new(big.Rat).Mul(m[0][col], determinant(minor))
+		if col%2 == 0 {
+			det.Add(det, term)
+		} else {
+			det.Sub(det, term)
+		}
+	}
+	return det
+}
+
+func sign(x float64) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
+
+// nudged returns x moved by up to n units in the last place.
+func nudged(random *rand.Rand, x float64, n int) float64 {
+	for i := random.Intn(2*n+1) - n; i != 0; {
+		if i > 0 {
+			x = math.Nextafter(x, math.Inf(1))
+			i--
+		} else {
+			x = math.Nextafter(x, math.Inf(-1))
+			i++
+		}
+	}
+	return x
+}
+
+func TestOrient2D(t *testing.T) {
+	a, b, c := vec2.T{0, 0}, vec2.T{1, 0}, vec2.T{0, 1}
+	if Orient2D(&a, &b, &c) <= 0 || Orient2D(&a, &c, &b) >= 0 || Orient2D(&a, &b, &b) != 0 {
+		t.Errorf("Orient2D failed for simple triangle")
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		// Points near the line y = x
+		p := [3]vec2.T{}
+		for j := range p {
+			v := random.Float64() * 100
+			p[j] = vec2.T{nudged(random, v, 2), nudged(random, v, 2)}
+		}
+		want := exactSign([][]float64{p[0][:], p[1][:], p[2][:]}, false)
+		if got := sign(Orient2D(&p[0], &p[1], &p[2])); got != want {
+			t.Fatalf("Orient2D(%v) failed: got %d, want %d", p, got, want)
+		}
+	}
+}
+
+func TestOrient3D(t *testing.T) {
+	a, b, c, d := vec3.T{0, 0, 0}, vec3.T{1, 0, 0}, vec3.T{0, 1, 0}, vec3.T{0, 0, 1}
+	if Orient3D(&a, &b, &c, &d) >= 0 {
+		t.Errorf("Orient3D failed: point above the plane must be negative")
+	}
+	d = vec3.T{0, 0, -1}
+	if Orient3D(&a, &b, &c, &d) <= 0 {
+		t.Errorf("Orient3D failed: point below the plane must be positive")
+	}
+
+	random := rand.New(rand.NewSource(2))
+	for i := 0; i < 10000; i++ {
+		// Points near the plane x + y + z = 1
+		p := [4]vec3.T{}
+		for j := range p {
+			x, y := random.Float64()*10, random.Float64()*10
+			p[j] = vec3.T{nudged(random, x, 2), nudged(random, y, 2), nudged(random, 1-x-y, 2)}
+		}
+		want := exactSign([][]float64{p[0][:], p[1][:], p[2][:], p[3][:]}, false)
+		if got := sign(Orient3D(&p[0], &p[1], &p[2], &p[3])); got != want {
+			t.Fatalf("Orient3D(%v) failed: got %d, want %d", p, got, want)
+		}
+	}
+}
+
+func TestInCircle(t *testing.T) {
+	a, b, c := vec2.T{1, 0}, vec2.T{0, 1}, vec2.T{-1, 0}
+	inside, outside, on := vec2.T{0, 0}, vec2.T{2, 2}, vec2.T{0, -1}
+	if InCircle(&a, &b, &c, &inside) <= 0 || InCircle(&a, &b, &c, &outside) >= 0 || InCircle(&a, &b, &c, &on) != 0 {
+		t.Errorf("InCircle failed for unit circle")
+	}
+
+	random := rand.New(rand.NewSource(3))
+	for i := 0; i < 10000; i++ {
+		// Points near the circle with radius 10 around (3, 5)
+		p := [4]vec2.T{}
+		for j := range p {
+			angle := random.Float64() * 2 * math.Pi
+			p[j] = vec2.T{nudged(random, 3+10*math.Cos(angle), 2), nudged(random, 5+10*math.Sin(angle), 2)}
+		}
+		want := exactSign([][]float64{p[0][:], p[1][:], p[2][:], p[3][:]}, true)
+		if got := sign(InCircle(&p[0], &p[1], &p[2], &p[3])); got != want {
+			t.Fatalf("InCircle(%v) failed: got %d, want %d", p, got, want)
+		}
+	}
+}
+
+func TestInSphere(t *testing.T) {
+	a, b, c, d := vec3.T{1, 0, 0}, vec3.T{0, 1, 0}, vec3.T{-1, 0, 0}, vec3.T{0, 0, -1}
+	if Orient3D(&a, &b, &c, &d) <= 0 {
+		t.Fatalf("Orient3D failed for sphere points")
+	}
+	inside, outside, on := vec3.T{0, 0, 0}, vec3.T{2, 2, 2}, vec3.T{0, 0, 1}
+	if InSphere(&a, &b, &c, &d, &inside) <= 0 || InSphere(&a, &b, &c, &d, &outside) >= 0 || InSphere(&a, &b, &c, &d, &on) != 0 {
+		t.Errorf("InSphere failed for unit sphere")
+	}
+
+	random := rand.New(rand.NewSource(4))
+	for i := 0; i < 1000; i++ {
+		// Points near the sphere with radius 10 around (3, 5, 7)
+		p := [5]vec3.T{}
+		for j := range p {
+			v := vec3.T{random.NormFloat64(), random.NormFloat64(), random.NormFloat64()}
+			v.Normalize()
+			for k := range v {
+				p[j][k] = nudged(random, float64(3+2*k)+10*v[k], 2)
+			}
+		}
+		want := exactSign([][]float64{p[0][:], p[1][:], p[2][:], p[3][:], p[4][:]}, true)
+		if got := sign(InSphere(&p[0], &p[1], &p[2], &p[3], &p[4])); got != want {
+			t.Fatalf("InSphere(%v) failed: got %d, want %d", p, got, want)
+		}
+	}
+}
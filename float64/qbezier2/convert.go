@@ -0,0 +1,40 @@
+package qbezier2
+
+import (
+	"math"
+
+	"github.com/ungerik/go3d/float64/bezier2"
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// FromCubic approximates the cubic bezier spline with quadratic bezier splines,
+// as needed for example by TrueType fonts, and appends them to dst.
+// The cubic spline is split at uniform parameter intervals into as few parts as needed
+// so that each quadratic spline deviates at most tolerance from its part.
+// The quadratic splines share their end points but their tangents may differ slightly.
+// A cubic spline that was elevated from a quadratic spline results in that spline.
+// tolerance must be greater than zero.
+func FromCubic(bez *bezier2.T, tolerance float64, dst []T) []T {
+	// The maximum distance between the cubic spline and the quadratic spline
+	// with the control point (3*(P1+P2) - P0 - P3) / 4 is sqrt(3)/36 * |P3 - 3*P2 + 3*P1 - P0|,
+	// which decreases with the third power of the number of uniform parts.
+	d := vec2.Sub(&bez.P1, &bez.P2)
+	d.Scale(3).Add(&bez.P3).Sub(&bez.P0)
+	bound := math.Sqrt(3) / 36 * d.Length()
+	parts := 1
+	if bound > tolerance {
+		parts = int(math.Ceil(math.Cbrt(bound / tolerance)))
+	}
+
+	rest := *bez
+	for i := 0; i < parts; i++ {
+		part := rest
+		if i < parts-1 {
+			part, rest = rest.Split(1 / float64(parts-i))
+		}
+		control := vec2.Add(&part.P1, &part.P2)
+		control.Scale(3).Sub(&part.P0).Sub(&part.P3).Scale(0.25)
+		dst = append(dst, T{part.P0, control, part.P3})
+	}
+	return dst
+}
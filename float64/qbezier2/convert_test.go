@@ -0,0 +1,53 @@
+package qbezier2
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/float64/bezier2"
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+func TestFromCubic(t *testing.T) {
+	cubics := []bezier2.T{
+		{P0: vec2.T{0, 0}, P1: vec2.T{1, 3}, P2: vec2.T{2, -1}, P3: vec2.T{3, 0}},
+		{P0: vec2.T{0, 0}, P1: vec2.T{3, 2}, P2: vec2.T{-1, 2}, P3: vec2.T{2, 0}},
+		{P0: vec2.T{0, 0}, P1: vec2.T{1, 1}, P2: vec2.T{2, 2}, P3: vec2.T{3, 3}},
+	}
+	for _, c := range cubics {
+		for _, tolerance := range []float64{0.1, 0.01, 0.001} {
+			quads := FromCubic(&c, tolerance, nil)
+			if quads[0].P0 != c.P0 || quads[len(quads)-1].P2 != c.P3 {
+				t.Errorf("quadratic splines of %v do not start and end at the end points: %v", c, quads)
+			}
+			for i := 1; i < len(quads); i++ {
+				if quads[i].P0 != quads[i-1].P2 {
+					t.Errorf("quadratic spline %d of %v does not start at the end of the previous one", i, c)
+				}
+			}
+			// Each quadratic spline approximates a uniform part of the cubic spline
+			n := float64(len(quads))
+			for s := float64(0); s <= 1; s += 1.0 / 256 {
+				i := min(int(s*n), len(quads)-1)
+				q := quads[i].Point(s*n - float64(i))
+				p := c.Point(s)
+				if d := vec2.Sub(&p, &q); d.Length() > tolerance+EPSILON {
+					t.Errorf("quadratic approximation of %v with tolerance %f deviates %f at t=%f", c, tolerance, d.Length(), s)
+				}
+			}
+		}
+	}
+	if coarse, fine := FromCubic(&cubics[1], 0.1, nil), FromCubic(&cubics[1], 0.0001, nil); len(fine) <= len(coarse) {
+		t.Errorf("smaller tolerance should create more splines: %d <= %d", len(fine), len(coarse))
+	}
+	if line := FromCubic(&cubics[2], 0.0001, nil); len(line) != 1 {
+		t.Errorf("straight cubic spline should result in one quadratic spline, got %v", line)
+	}
+
+	for _, b := range testCurves {
+		cubic := b.Cubic()
+		quads := FromCubic(&cubic, 0.0001, nil)
+		if len(quads) != 1 || !quads[0].P1.PracticallyEquals(&b.P1, EPSILON) {
+			t.Errorf("elevated quadratic spline %v should convert back to itself, got %v", b, quads)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package qbezier2
+
+import (
+	"github.com/ungerik/go3d/float64/bezier2"
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+// Nearest returns the parameter t (0,1) of the point on the spline
+// that is nearest to p, the point itself and its distance to p.
+// See bezier2.T.Nearest().
+func (bez *T) Nearest(p *vec2.T) (t float64, point vec2.T, distance float64) {
+	cubic := bez.Cubic()
+	return cubic.Nearest(p)
+}
+
+// IntersectLine appends the intersections of the spline with the infinite line
+// through a and b to dst, sorted by their parameter on the spline.
+// See bezier2.T.IntersectLine().
+func (bez *T) IntersectLine(a, b *vec2.T, tolerance float64, dst []bezier2.Intersection) []bezier2.Intersection {
+	cubic := bez.Cubic()
+	return cubic.IntersectLine(a, b, tolerance, dst)
+}
+
+// Intersect appends the intersections of the spline with other to dst,
+// sorted by their parameter T on the spline.
+// See bezier2.T.Intersect().
+func (bez *T) Intersect(other *T, tolerance float64, dst []bezier2.Intersection) []bezier2.Intersection {
+	cubic := bez.Cubic()
+	otherCubic := other.Cubic()
+	return cubic.Intersect(&otherCubic, tolerance, dst)
+}
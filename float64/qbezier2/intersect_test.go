@@ -0,0 +1,32 @@
+package qbezier2
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+func TestNearest(t *testing.T) {
+	b := testCurves[0]
+	p := vec2.T{1, 2}
+	param, point, distance := b.Nearest(&p)
+	if abs(param-0.5) > EPSILON || abs(distance-1.5) > EPSILON || !point.PracticallyEquals(&vec2.T{1, 0.5}, EPSILON) {
+		t.Errorf("nearest point of %v failed, got t=%f, %v, distance %f", p, param, point, distance)
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	// y(t) = 2t(1-t) crosses y = 0.375 at t = 0.25 and t = 0.75
+	b := testCurves[0]
+	a, c := vec2.T{0, 0.375}, vec2.T{2, 0.375}
+	hits := b.IntersectLine(&a, &c, 0.0001, nil)
+	if len(hits) != 2 || abs(hits[0].T-0.25) > 0.001 || abs(hits[1].T-0.75) > 0.001 {
+		t.Errorf("line intersections failed, got %v", hits)
+	}
+
+	mirrored := T{vec2.T{0, 0.75}, vec2.T{1, -0.25}, vec2.T{2, 0.75}}
+	hits = b.Intersect(&mirrored, 0.0001, nil)
+	if len(hits) != 2 || abs(hits[0].T-0.25) > 0.001 || abs(hits[1].T-0.75) > 0.001 {
+		t.Errorf("curve intersections failed, got %v", hits)
+	}
+}
@@ -1,6 +1,7 @@
 package qbezier2
 
 import (
+	"math"
 	"testing"
 
 	"github.com/ungerik/go3d/float64/vec2"
@@ -43,3 +44,32 @@ func TestTangent(t *testing.T) {
 		t.Errorf("quadratic bezier tangent at t=0.75 failed, got %v, want %v", got, want)
 	}
 }
+
+func TestLength(t *testing.T) {
+	b := T{vec2.T{0, 0}, vec2.T{1, 1}, vec2.T{2, 0}}
+	// Reference value from the closed form solution
+	const want = 2.2955871493867663
+	if got := b.Length(1); math.Abs(got-want) > 0.0001 {
+		t.Errorf("quadratic bezier length failed, got %f, want %f", got, want)
+	}
+	// The spline is symmetric, so half of it is reached at t=0.5
+	if got := b.Length(0.5); math.Abs(got-want/2) > 0.0001 {
+		t.Errorf("quadratic bezier length at t=0.5 failed, got %f, want %f", got, want/2)
+	}
+	if got := b.LengthEps(0.25, 1e-3); math.Abs(got-b.Length(0.25)) > 0.001 {
+		t.Errorf("quadratic bezier length with low tolerance failed, got %f, want %f", got, b.Length(0.25))
+	}
+}
+
+func TestArcLengthTable(t *testing.T) {
+	b := T{vec2.T{0, 0}, vec2.T{1, 1}, vec2.T{2, 0}}
+	table := b.ArcLengthTable(8)
+	params := table.UniformParams(3, nil)
+	if math.Abs(params[1]-0.5) > 0.0001 {
+		t.Errorf("middle uniform parameter of symmetric spline should be 0.5, got %f", params[1])
+	}
+	quarter := table.Param(table.Length() / 4)
+	if got, want := b.Length(quarter), table.Length()/4; math.Abs(got-want) > 0.0001 {
+		t.Errorf("length at parameter of quarter distance failed, got %f, want %f", got, want)
+	}
+}
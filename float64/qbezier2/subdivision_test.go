@@ -0,0 +1,89 @@
+package qbezier2
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/float64/vec2"
+)
+
+const EPSILON = 0.0001
+
+var testCurves = []T{
+	{vec2.T{0, 0}, vec2.T{1, 1}, vec2.T{2, 0}},
+	{vec2.T{0, 0}, vec2.T{3, 2}, vec2.T{-1, 1}},
+	{vec2.T{1, 1}, vec2.T{4, 4}, vec2.T{5, 5}},
+}
+
+func TestSplit(t *testing.T) {
+	for _, b := range testCurves {
+		left, right := b.Split(0.3)
+		for _, s := range []float64{0, 0.25, 0.5, 0.75, 1} {
+			if got, want := left.Point(s), b.Point(0.3*s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("left part of %v at %f failed, got %v, want %v", b, s, got, want)
+			}
+			if got, want := right.Point(s), b.Point(0.3+0.7*s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("right part of %v at %f failed, got %v, want %v", b, s, got, want)
+			}
+		}
+	}
+}
+
+func TestSubCurve(t *testing.T) {
+	for _, b := range testCurves {
+		sub := b.SubCurve(0.2, 0.7)
+		reversed := b.SubCurve(0.7, 0.2)
+		for _, s := range []float64{0, 0.25, 0.5, 0.75, 1} {
+			if got, want := sub.Point(s), b.Point(0.2+0.5*s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("sub curve of %v at %f failed, got %v, want %v", b, s, got, want)
+			}
+			if got, want := reversed.Point(s), b.Point(0.7-0.5*s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("reversed sub curve of %v at %f failed, got %v, want %v", b, s, got, want)
+			}
+		}
+	}
+}
+
+func TestCubic(t *testing.T) {
+	for _, b := range testCurves {
+		cubic := b.Cubic()
+		for _, s := range []float64{0, 0.1, 0.25, 0.5, 0.75, 0.9, 1} {
+			if got, want := cubic.Point(s), b.Point(s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("elevated curve of %v at %f failed, got %v, want %v", b, s, got, want)
+			}
+		}
+	}
+}
+
+func TestExtremaAndBoundingBox(t *testing.T) {
+	b := testCurves[0]
+	if got := b.Extrema(nil); len(got) != 1 || got[0] != 0.5 {
+		t.Errorf("extrema of symmetric curve failed, got %v, want [0.5]", got)
+	}
+	if got, want := b.BoundingBox(), (vec2.Rect{Min: vec2.T{0, 0}, Max: vec2.T{2, 0.5}}); got != want {
+		t.Errorf("bounding box failed, got %v, want %v", got, want)
+	}
+
+	// x has a maximum at t=3/7 and y at t=2/3
+	b = testCurves[1]
+	extrema := b.Extrema(nil)
+	if len(extrema) != 2 || abs(extrema[0]-3.0/7.0) > EPSILON || abs(extrema[1]-2.0/3.0) > EPSILON {
+		t.Errorf("extrema failed, got %v, want [%f %f]", extrema, 3.0/7.0, 2.0/3.0)
+	}
+	rect := b.BoundingBox()
+	xMax := b.Point(3.0 / 7.0)
+	yMax := b.Point(2.0 / 3.0)
+	if want := (vec2.Rect{Min: vec2.T{-1, 0}, Max: vec2.T{xMax[0], yMax[1]}}); !rect.Min.PracticallyEquals(&want.Min, EPSILON) || !rect.Max.PracticallyEquals(&want.Max, EPSILON) {
+		t.Errorf("bounding box failed, got %v, want %v", rect, want)
+	}
+
+	if got := testCurves[2].Extrema(nil); len(got) != 0 {
+		t.Errorf("monotonic curve should have no extrema, got %v", got)
+	}
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,48 @@
+package qbezier3
+
+import (
+	"math"
+
+	"github.com/ungerik/go3d/float64/bezier3"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// Cubic returns the cubic bezier spline that describes exactly
+// the same curve as the quadratic spline (degree elevation).
+func (bez *T) Cubic() bezier3.T {
+	c1 := vec3.Interpolate(&bez.P0, &bez.P1, 2.0/3.0)
+	c2 := vec3.Interpolate(&bez.P2, &bez.P1, 2.0/3.0)
+	return bezier3.T{P0: bez.P0, P1: c1, P2: c2, P3: bez.P2}
+}
+
+// FromCubic approximates the cubic bezier spline with quadratic bezier splines,
+// as needed for example by TrueType fonts, and appends them to dst.
+// The cubic spline is split at uniform parameter intervals into as few parts as needed
+// so that each quadratic spline deviates at most tolerance from its part.
+// The quadratic splines share their end points but their tangents may differ slightly.
+// A cubic spline that was elevated from a quadratic spline results in that spline.
+// tolerance must be greater than zero.
+func FromCubic(bez *bezier3.T, tolerance float64, dst []T) []T {
+	// The maximum distance between the cubic spline and the quadratic spline
+	// with the control point (3*(P1+P2) - P0 - P3) / 4 is sqrt(3)/36 * |P3 - 3*P2 + 3*P1 - P0|,
+	// which decreases with the third power of the number of uniform parts.
+	d := vec3.Sub(&bez.P1, &bez.P2)
+	d.Scale(3).Add(&bez.P3).Sub(&bez.P0)
+	bound := math.Sqrt(3) / 36 * d.Length()
+	parts := 1
+	if bound > tolerance {
+		parts = int(math.Ceil(math.Cbrt(bound / tolerance)))
+	}
+
+	rest := *bez
+	for i := 0; i < parts; i++ {
+		part := rest
+		if i < parts-1 {
+			part, rest = rest.Split(1 / float64(parts-i))
+		}
+		control := vec3.Add(&part.P1, &part.P2)
+		control.Scale(3).Sub(&part.P0).Sub(&part.P3).Scale(0.25)
+		dst = append(dst, T{part.P0, control, part.P3})
+	}
+	return dst
+}
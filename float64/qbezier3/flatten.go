@@ -0,0 +1,41 @@
+package qbezier3
+
+import (
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+// MaxFlattenDepth limits the recursive subdivision of Flatten,
+// so at most 2^MaxFlattenDepth line segments are created per spline.
+const MaxFlattenDepth = 16
+
+// Flatten approximates the quadratic bezier spline with a polyline
+// by recursive subdivision until every part is flat enough.
+// The points of the polyline are appended to dst, starting with P0 and ending with P2.
+// The distance between any point of the spline and the polyline is at most tolerance,
+// unless a part needs more than MaxFlattenDepth subdivisions.
+// No memory is allocated if dst has enough capacity.
+func (bez *T) Flatten(tolerance float64, dst []vec3.T) []vec3.T {
+	dst = append(dst, bez.P0)
+	return bez.flatten(16*tolerance*tolerance, MaxFlattenDepth, dst)
+}
+
+func (bez *T) flatten(limit float64, depth int, dst []vec3.T) []vec3.T {
+	if depth == 0 || bez.flatness() <= limit {
+		return append(dst, bez.P2)
+	}
+	left, right := bez.Split(0.5)
+	dst = left.flatten(limit, depth-1, dst)
+	return right.flatten(limit, depth-1, dst)
+}
+
+// flatness returns 16 times the squared maximum distance
+// between the spline and the line from P0 to P2,
+// which is reached at t=0.5 with a quarter of the length of 2*P1 - P0 - P2.
+func (bez *T) flatness() float64 {
+	var sum float64
+	for i := range bez.P0 {
+		d := 2*bez.P1[i] - bez.P0[i] - bez.P2[i]
+		sum += d * d
+	}
+	return sum
+}
@@ -0,0 +1,144 @@
+package sphere
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ungerik/go3d/float64/mat4"
+	"github.com/ungerik/go3d/float64/vec3"
+)
+
+const EPSILON = 0.0001
+
+func abs(x float64) float64 {
+	return math.Abs(x)
+}
+
+// checkSphere checks that the sphere contains all points and has the wanted center and radius.
+func checkSphere(t *testing.T, name string, sphere T, points []vec3.T, center vec3.T, radius float64) {
+	t.Helper()
+	for i := range points {
+		if !sphere.ContainsPoint(&points[i]) {
+			t.Errorf("%s failed: point %v is not contained in %v", name, points[i], sphere)
+		}
+	}
+	if vec3.Distance(&sphere.Center, &center) > EPSILON || abs(sphere.Radius-radius) > EPSILON {
+		t.Errorf("%s failed: got %v, want center %v and radius %f", name, sphere, center, radius)
+	}
+}
+
+func TestMinimalFromPoints(t *testing.T) {
+	cube := []vec3.T{
+		{-1, -1, -1}, {1, -1, -1}, {-1, 1, -1}, {1, 1, -1},
+		{-1, -1, 1}, {1, -1, 1}, {-1, 1, 1}, {1, 1, 1},
+	}
+	checkSphere(t, "cube", MinimalFromPoints(cube), cube, vec3.Zero, math.Sqrt(3))
+
+	// Points inside of and on the sphere around (1, 2, 3) with radius 5
+	rnd := rand.New(rand.NewSource(1))
+	center := vec3.T{1, 2, 3}
+	points := []vec3.T{{6, 2, 3}, {-4, 2, 3}, {1, 7, 3}, {1, -3, 3}, {1, 2, 8}, {1, 2, -2}}
+	for i := 0; i < 1000; i++ {
+		d := vec3.T{rnd.NormFloat64(), rnd.NormFloat64(), rnd.NormFloat64()}
+		d.Normalize()
+		d.Scale(5 * rnd.Float64())
+		points = append(points, vec3.Add(&center, &d))
+	}
+	minimal := MinimalFromPoints(points)
+	checkSphere(t, "ball", minimal, points, center, 5)
+	ritter := FromPoints(points)
+	checkSphere(t, "Ritter", ritter, points, ritter.Center, ritter.Radius)
+	if ritter.Radius < minimal.Radius {
+		t.Errorf("Ritter failed: radius %f is smaller than the minimal radius %f", ritter.Radius, minimal.Radius)
+	}
+
+	// The circumcircle of an acute triangle
+	triangle := []vec3.T{{0, 0, 0}, {4, 0, 0}, {2, 3, 0}}
+	checkSphere(t, "triangle", MinimalFromPoints(triangle), triangle, vec3.T{2, 5 / 6.0, 0}, 13/6.0)
+	// The longest edge of an obtuse triangle
+	obtuse := []vec3.T{{0, 0, 0}, {4, 0, 0}, {2, 1, 0}}
+	checkSphere(t, "obtuse triangle", MinimalFromPoints(obtuse), obtuse, vec3.T{2, 0, 0}, 2)
+}
+
+func TestMinimalFromPointsDegenerated(t *testing.T) {
+	if sphere := MinimalFromPoints(nil); sphere != (T{}) {
+		t.Errorf("no points failed: got %v", sphere)
+	}
+	single := []vec3.T{{1, 2, 3}, {1, 2, 3}}
+	checkSphere(t, "single point", MinimalFromPoints(single), single, vec3.T{1, 2, 3}, 0)
+	collinear := []vec3.T{{0, 0, 0}, {1, 1, 1}, {3, 3, 3}, {2, 2, 2}, {1, 1, 1}}
+	checkSphere(t, "collinear", MinimalFromPoints(collinear), collinear, vec3.T{1.5, 1.5, 1.5}, math.Sqrt(27)/2)
+	// Cospherical and coplanar points on a circle
+	var circle []vec3.T
+	for i := 0; i < 16; i++ {
+		angle := float64(i) * math.Pi / 8
+		circle = append(circle, vec3.T{math.Cos(angle), math.Sin(angle), 1})
+	}
+	checkSphere(t, "circle", MinimalFromPoints(circle), circle, vec3.T{0, 0, 1}, 1)
+}
+
+func TestContains(t *testing.T) {
+	sphere := T{Center: vec3.T{1, 0, 0}, Radius: 2}
+	if p := (vec3.T{3, 0, 0}); !sphere.ContainsPoint(&p) {
+		t.Errorf("ContainsPoint failed for point on the surface")
+	}
+	if p := (vec3.T{2, 2, 0}); sphere.ContainsPoint(&p) {
+		t.Errorf("ContainsPoint failed for point outside")
+	}
+	inner := T{Center: vec3.T{2, 0, 0}, Radius: 1}
+	if !sphere.ContainsSphere(&inner) || inner.ContainsSphere(&sphere) {
+		t.Errorf("ContainsSphere failed")
+	}
+	other := T{Center: vec3.T{5, 0, 0}, Radius: 2}
+	far := T{Center: vec3.T{5, 1, 0}, Radius: 2}
+	if !sphere.Intersects(&other) || sphere.Intersects(&far) {
+		t.Errorf("Intersects failed")
+	}
+	box := vec3.Box{Min: vec3.T{2, 1, -1}, Max: vec3.T{4, 3, 1}}
+	corner := vec3.Box{Min: vec3.T{2.5, 1.5, 1}, Max: vec3.T{4, 3, 2}}
+	if !sphere.IntersectsBox(&box) || sphere.IntersectsBox(&corner) {
+		t.Errorf("IntersectsBox failed")
+	}
+}
+
+func TestJoin(t *testing.T) {
+	a := T{Center: vec3.T{0, 0, 0}, Radius: 1}
+	b := T{Center: vec3.T{4, 0, 0}, Radius: 2}
+	joined := Joined(&a, &b)
+	if joined.Center != (vec3.T{2.5, 0, 0}) || joined.Radius != 3.5 {
+		t.Errorf("Joined failed: got %v", joined)
+	}
+	inner := T{Center: vec3.T{4.5, 0, 0}, Radius: 1}
+	if joined := Joined(&inner, &b); joined != b {
+		t.Errorf("Joined of contained sphere failed: got %v", joined)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	// Rotation around the Z axis by 90 degrees with the scaling (2, 3, 1) and a translation
+	mat := mat4.T{
+		{0, 2, 0, 0},
+		{-3, 0, 0, 0},
+		{0, 0, 1, 0},
+		{1, 1, 1, 1},
+	}
+	sphere := T{Center: vec3.T{1, 0, 0}, Radius: 1}
+	transformed := sphere.Transformed(&mat)
+	want := mat.MulVec3(&sphere.Center)
+	if vec3.Distance(&transformed.Center, &want) > EPSILON || abs(transformed.Radius-3) > EPSILON {
+		t.Errorf("Transformed failed: got %v, want center %v and radius 3", transformed, want)
+	}
+	// Shear x' = x + y scales by the golden ratio along its major axis
+	shear := mat4.T{
+		{1, 0, 0, 0},
+		{1, 1, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	}
+	sheared := T{Radius: 1}
+	sheared.Transform(&shear)
+	if want := (1 + math.Sqrt(5)) / 2; abs(sheared.Radius-want) > EPSILON {
+		t.Errorf("Transform with shear failed: got radius %v, want %v", sheared.Radius, want)
+	}
+}
@@ -0,0 +1,207 @@
+package frame
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/bezier3"
+	hermit "github.com/ungerik/go3d/hermit3"
+	"github.com/ungerik/go3d/nurbs"
+	"github.com/ungerik/go3d/qbezier3"
+	"github.com/ungerik/go3d/vec3"
+)
+
+const EPSILON = 0.0001
+
+var (
+	_ SecondDerivativeCurve = &hermit.T{}
+	_ SecondDerivativeCurve = &bezier3.T{}
+	_ SecondDerivativeCurve = &qbezier3.T{}
+	_ SecondDerivativeCurve = &nurbs.Curve{}
+)
+
+// twistedCubic is the curve (t, t^2, t^3) for t (0,1).
+var twistedCubic = bezier3.T{
+	P0: vec3.T{0, 0, 0},
+	P1: vec3.T{1.0 / 3, 0, 0},
+	P2: vec3.T{2.0 / 3, 1.0 / 3, 0},
+	P3: vec3.T{1, 1, 1},
+}
+
+func abs(x float32) float32 {
+	return float32(math.Abs(float64(x)))
+}
+
+func checkOrthonormal(t *testing.T, frame *Frame) {
+	for _, v := range []*vec3.T{&frame.Tangent, &frame.Normal, &frame.Binormal} {
+		if abs(v.Length()-1) > EPSILON {
+			t.Errorf("frame vector %v is not normalized", v)
+		}
+	}
+	if abs(vec3.Dot(&frame.Tangent, &frame.Normal)) > EPSILON {
+		t.Errorf("tangent %v and normal %v are not orthogonal", frame.Tangent, frame.Normal)
+	}
+	b := vec3.Cross(&frame.Tangent, &frame.Normal)
+	if !b.PracticallyEquals(&frame.Binormal, EPSILON) {
+		t.Errorf("binormal %v is not tangent x normal %v", frame.Binormal, b)
+	}
+}
+
+func TestFrenetFrame(t *testing.T) {
+	// For (t, t^2, t^3) at t = 0.5:
+	// d1 = (1, 1, 0.75), d2 = (0, 2, 3), d3 = (0, 0, 6), d1 x d2 = (1.5, -3, 2)
+	frenet := FrenetFrame(&twistedCubic, 0.5)
+	checkOrthonormal(t, &frenet.Frame)
+	if want := twistedCubic.Point(0.5); frenet.Point != want {
+		t.Errorf("point failed: got %v, want %v", frenet.Point, want)
+	}
+	wantTangent := vec3.T{1, 1, 0.75}
+	wantTangent.Normalize()
+	wantBinormal := vec3.T{1.5, -3, 2}
+	wantBinormal.Normalize()
+	if !frenet.Tangent.PracticallyEquals(&wantTangent, EPSILON) || !frenet.Binormal.PracticallyEquals(&wantBinormal, EPSILON) {
+		t.Errorf("frame failed: got tangent %v and binormal %v, want %v and %v", frenet.Tangent, frenet.Binormal, wantTangent, wantBinormal)
+	}
+	if want := twistedCubic.Curvature(0.5); abs(frenet.Curvature-want) > EPSILON {
+		t.Errorf("curvature failed: got %f, want %f", frenet.Curvature, want)
+	}
+	if want := float32(12 / 15.25); abs(frenet.Torsion-want) > 0.001 {
+		t.Errorf("torsion failed: got %f, want %f", frenet.Torsion, want)
+	}
+	// The normal points into the curve
+	d2 := twistedCubic.SecondDerivative(0.5)
+	if vec3.Dot(&frenet.Normal, &d2) <= 0 {
+		t.Errorf("normal %v does not point to the center of curvature", frenet.Normal)
+	}
+
+	// A planar curve has no torsion and a constant binormal
+	herm := hermit.T{
+		A: hermit.PointTangent{Point: vec3.T{0, 0, 0}, Tangent: vec3.T{3, 3, 0}},
+		B: hermit.PointTangent{Point: vec3.T{2, 0, 0}, Tangent: vec3.T{3, -3, 0}},
+	}
+	for _, s := range []float32{0, 0.3, 0.7, 1} {
+		frenet := FrenetFrame(&herm, s)
+		checkOrthonormal(t, &frenet.Frame)
+		if abs(frenet.Torsion) > 0.001 || !frenet.Binormal.PracticallyEquals(&vec3.T{0, 0, -1}, EPSILON) {
+			t.Errorf("frame of planar curve at %f failed: %v", s, frenet)
+		}
+	}
+
+	// A circle has the curvature of its inverse radius
+	center := vec3.T{0, 0, 0}
+	xAxis := vec3.T{2, 0, 0}
+	yAxis := vec3.T{0, 0, 2}
+	circle := nurbs.Arc(&center, &xAxis, &yAxis, 0, math.Pi)
+	frenet = FrenetFrame(&circle, 0.3)
+	inward := frenet.Point.Scaled(-0.5)
+	if abs(frenet.Curvature-0.5) > EPSILON || !frenet.Normal.PracticallyEquals(&inward, EPSILON) {
+		t.Errorf("frame of circle failed: %v", frenet)
+	}
+}
+
+func TestFrenetFrameStraight(t *testing.T) {
+	line := bezier3.T{P0: vec3.T{0, 0, 0}, P1: vec3.T{1, 2, 3}, P2: vec3.T{2, 4, 6}, P3: vec3.T{3, 6, 9}}
+	frenet := FrenetFrame(&line, 0.4)
+	checkOrthonormal(t, &frenet.Frame)
+	want := vec3.T{1, 2, 3}
+	want.Normalize()
+	if !frenet.Tangent.PracticallyEquals(&want, EPSILON) || frenet.Curvature != 0 || frenet.Torsion != 0 {
+		t.Errorf("frame of straight line failed: %v", frenet)
+	}
+}
+
+func TestRotationMinimizing(t *testing.T) {
+	params := make([]float32, 101)
+	for i := range params {
+		params[i] = float32(i) / 100
+	}
+
+	// The normal of a planar curve stays perpendicular to the plane
+	planar := bezier3.T{P0: vec3.T{0, 0, 0}, P1: vec3.T{1, 3, 0}, P2: vec3.T{2, -3, 0}, P3: vec3.T{3, 0, 0}}
+	frames := RotationMinimizing(&planar, params, &vec3.UnitZ, nil)
+	if len(frames) != len(params) {
+		t.Fatalf("expected %d frames, got %d", len(params), len(frames))
+	}
+	for i := range frames {
+		checkOrthonormal(t, &frames[i])
+		if !frames[i].Normal.PracticallyEquals(&vec3.UnitZ, EPSILON) {
+			t.Errorf("normal %v of planar curve at %f is not constant", frames[i].Normal, params[i])
+		}
+	}
+
+	// The initial normal is projected onto the plane perpendicular to the tangent
+	initial := vec3.T{1, 1, 0}
+	frames = RotationMinimizing(&twistedCubic, params, &initial, frames[:0])
+	if !frames[0].Normal.PracticallyEquals(&vec3.UnitY, EPSILON) {
+		t.Errorf("initial normal failed: got %v, want %v", frames[0].Normal, vec3.UnitY)
+	}
+	for i := range frames {
+		checkOrthonormal(t, &frames[i])
+		tangent := twistedCubic.Tangent(params[i])
+		tangent.Normalize()
+		if !frames[i].Tangent.PracticallyEquals(&tangent, EPSILON) {
+			t.Errorf("tangent at %f failed: got %v, want %v", params[i], frames[i].Tangent, tangent)
+		}
+		// Without twist the normal does not rotate towards the binormal
+		if i > 0 {
+			if twist := vec3.Dot(&frames[i].Normal, &frames[i-1].Binormal); abs(twist) > 0.001 {
+				t.Errorf("frame at %f twists by %f", params[i], twist)
+			}
+		}
+	}
+
+	if frames := RotationMinimizing(&twistedCubic, params[:1], nil, nil); len(frames) != 1 {
+		t.Errorf("expected one frame, got %v", frames)
+	} else {
+		checkOrthonormal(t, &frames[0])
+	}
+}
+
+func TestDegeneratedTangents(t *testing.T) {
+	// Coincident control points at the start
+	coincident := bezier3.T{P0: vec3.T{0, 0, 0}, P1: vec3.T{0, 0, 0}, P2: vec3.T{1, 1, 0}, P3: vec3.T{2, 0, 1}}
+	// Zero first derivative in the middle
+	cusp := bezier3.T{P0: vec3.T{0, 0, 0}, P1: vec3.T{2, 2, 0}, P2: vec3.T{2, 0, 0}, P3: vec3.T{0, 2, 0}}
+	quadratic := qbezier3.T{P0: vec3.T{0, 0, 0}, P1: vec3.T{0, 0, 0}, P2: vec3.T{2, 1, 0}}
+	params := []float32{0, 0.5, 1}
+	for _, curve := range []SecondDerivativeCurve{&coincident, &cusp, &quadratic} {
+		frames := RotationMinimizing(curve, params, nil, nil)
+		if len(frames) != len(params) {
+			t.Fatalf("expected %d frames, got %d", len(params), len(frames))
+		}
+		for i := range frames {
+			checkOrthonormal(t, &frames[i])
+		}
+		for _, param := range params {
+			frenet := FrenetFrame(curve, param)
+			checkOrthonormal(t, &frenet.Frame)
+		}
+	}
+
+	// The first tangent of the coincident control points points to the next point
+	frames := RotationMinimizing(&coincident, params, nil, nil)
+	next := coincident.Point(0.5)
+	next.Normalize()
+	if !frames[0].Tangent.PracticallyEquals(&next, EPSILON) {
+		t.Errorf("tangent at zero derivative failed: got %v, want %v", frames[0].Tangent, next)
+	}
+	// The cusp keeps the previous tangent
+	if frames := RotationMinimizing(&cusp, params, nil, nil); frames[1].Tangent != frames[0].Tangent {
+		t.Errorf("tangent at cusp failed: got %v, want %v", frames[1].Tangent, frames[0].Tangent)
+	}
+}
+
+func TestMat3AndQuaternion(t *testing.T) {
+	frenet := FrenetFrame(&twistedCubic, 0.5)
+	m := frenet.Mat3()
+	q := frenet.Quaternion()
+	for i, axis := range []vec3.T{vec3.UnitX, vec3.UnitY, vec3.UnitZ} {
+		want := []vec3.T{frenet.Tangent, frenet.Normal, frenet.Binormal}[i]
+		if got := m.MulVec3(&axis); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("matrix rotates %v to %v, want %v", axis, got, want)
+		}
+		if got := q.RotatedVec3(&axis); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("quaternion rotates %v to %v, want %v", axis, got, want)
+		}
+	}
+}
@@ -4,6 +4,9 @@ package hermit2
 
 import (
 	"fmt"
+
+	"github.com/ungerik/go3d/arclength"
+	"github.com/ungerik/go3d/bezier2"
 	"github.com/ungerik/go3d/vec2"
 )
 
@@ -50,10 +53,58 @@ func (herm *T) Tangent(t float32) vec2.T {
 }
 
 // Length returns the length of a hermit spline from A.Point to t (0,1).
+// See LengthEps for details.
 func (herm *T) Length(t float32) float32 {
 	return Length(&herm.A.Point, &herm.A.Tangent, &herm.B.Point, &herm.B.Tangent, t)
 }
 
+// LengthEps returns the length of a hermit spline from A.Point to t (0,1)
+// computed with adaptive Gauss-Legendre quadrature up to the relative tolerance epsilon.
+func (herm *T) LengthEps(t, epsilon float32) float32 {
+	return LengthEps(&herm.A.Point, &herm.A.Tangent, &herm.B.Point, &herm.B.Tangent, t, epsilon)
+}
+
+// ArcLengthTable returns a table that maps arc length distances on the spline
+// to t values, dividing the range (0,1) into segments intervals.
+// Use it to sample the spline at uniform spacing.
+func (herm *T) ArcLengthTable(segments int) arclength.Table {
+	return arclength.NewTable(func(t float32) float32 {
+		d := herm.Tangent(t)
+		return d.Length()
+	}, segments)
+}
+
+// Flatten approximates the hermit spline with a polyline
+// by recursive subdivision until every part is flat enough.
+// The points of the polyline are appended to dst, starting with A.Point and ending with B.Point.
+// The distance between any point of the spline and the polyline is at most tolerance,
+// unless a part needs more than bezier2.MaxFlattenDepth subdivisions.
+// No memory is allocated if dst has enough capacity.
+func (herm *T) Flatten(tolerance float32, dst []vec2.T) []vec2.T {
+	bez := herm.Bezier()
+	return bez.Flatten(tolerance, dst)
+}
+
+// Bezier returns the cubic bezier spline that describes the same curve.
+func (herm *T) Bezier() bezier2.T {
+	p1 := herm.A.Tangent.Scaled(1.0 / 3.0)
+	p1.Add(&herm.A.Point)
+	p2 := herm.B.Tangent.Scaled(-1.0 / 3.0)
+	p2.Add(&herm.B.Point)
+	return bezier2.T{P0: herm.A.Point, P1: p1, P2: p2, P3: herm.B.Point}
+}
+
+// FromBezier returns the hermit spline that describes the same curve as the cubic bezier spline.
+// The tangents are three times the vectors from the end points to the inner control points.
+func FromBezier(bez *bezier2.T) T {
+	tangentA := vec2.Sub(&bez.P1, &bez.P0)
+	tangentB := vec2.Sub(&bez.P3, &bez.P2)
+	return T{
+		A: PointTangent{Point: bez.P0, Tangent: tangentA.Scaled(3)},
+		B: PointTangent{Point: bez.P3, Tangent: tangentB.Scaled(3)},
+	}
+}
+
 // Point returns a point on a hermit spline at t (0,1).
 func Point(pointA, tangentA, pointB, tangentB *vec2.T, t float32) vec2.T {
 	t2 := t * t
@@ -78,49 +129,39 @@ func Point(pointA, tangentA, pointB, tangentB *vec2.T, t float32) vec2.T {
 }
 
 // Tangent returns a tangent on a hermit spline at t (0,1).
+// The tangent is the first derivative of the spline.
 func Tangent(pointA, tangentA, pointB, tangentB *vec2.T, t float32) vec2.T {
 	t2 := t * t
-	t3 := t2 * t
 
-	f := 2*t3 - 3*t2
+	f := 6*t2 - 6*t
 	result := pointA.Scaled(f)
 
-	f = t3 - 2*t2 + 1
+	f = 3*t2 - 4*t + 1
 	tAf := tangentA.Scaled(f)
 	result.Add(&tAf)
 
-	f = t3 - t2
+	f = 3*t2 - 2*t
 	tBf := tangentB.Scaled(f)
 	result.Add(&tBf)
 
-	f = -2*t3 + 3*t2
-	pAf := pointB.Scaled(f)
-	result.Add(&pAf)
+	f = -6*t2 + 6*t
+	pBf := pointB.Scaled(f)
+	result.Add(&pBf)
 
 	return result
 }
 
 // Length returns the length of a hermit spline from pointA to t (0,1).
+// See LengthEps for details.
 func Length(pointA, tangentA, pointB, tangentB *vec2.T, t float32) float32 {
-	sqrT := t * t
-	t1 := sqrT * 0.5
-	t2 := sqrT * t * 1.0 / 3.0
-	t3 := sqrT*sqrT + 1.0/4.0
-
-	f := 2*t3 - 3*t2 + t
-	result := pointA.Scaled(f)
-
-	f = t3 - 2*t2 + t1
-	tAf := tangentA.Scaled(f)
-	result.Add(&tAf)
-
-	f = t3 - t2
-	tBf := tangentB.Scaled(f)
-	result.Add(&tBf)
-
-	f = -2*t3 + 3*t2
-	pBf := pointB.Scaled(f)
-	result.Add(&pBf)
+	return LengthEps(pointA, tangentA, pointB, tangentB, t, arclength.DefaultTolerance)
+}
 
-	return result.Length()
+// LengthEps returns the length of a hermit spline from pointA to t (0,1)
+// computed with adaptive Gauss-Legendre quadrature up to the relative tolerance epsilon.
+func LengthEps(pointA, tangentA, pointB, tangentB *vec2.T, t, epsilon float32) float32 {
+	return arclength.Integrate(func(t float32) float32 {
+		d := Tangent(pointA, tangentA, pointB, tangentB, t)
+		return d.Length()
+	}, 0, t, epsilon)
 }
@@ -0,0 +1,31 @@
+package hermit2
+
+import (
+	"github.com/ungerik/go3d/bezier2"
+	"github.com/ungerik/go3d/vec2"
+)
+
+// Nearest returns the parameter t (0,1) of the point on the spline
+// that is nearest to p, the point itself and its distance to p.
+// See bezier2.T.Nearest().
+func (herm *T) Nearest(p *vec2.T) (t float32, point vec2.T, distance float32) {
+	cubic := herm.Bezier()
+	return cubic.Nearest(p)
+}
+
+// IntersectLine appends the intersections of the spline with the infinite line
+// through a and b to dst, sorted by their parameter on the spline.
+// See bezier2.T.IntersectLine().
+func (herm *T) IntersectLine(a, b *vec2.T, tolerance float32, dst []bezier2.Intersection) []bezier2.Intersection {
+	cubic := herm.Bezier()
+	return cubic.IntersectLine(a, b, tolerance, dst)
+}
+
+// Intersect appends the intersections of the spline with other to dst,
+// sorted by their parameter T on the spline.
+// See bezier2.T.Intersect().
+func (herm *T) Intersect(other *T, tolerance float32, dst []bezier2.Intersection) []bezier2.Intersection {
+	cubic := herm.Bezier()
+	otherCubic := other.Bezier()
+	return cubic.Intersect(&otherCubic, tolerance, dst)
+}
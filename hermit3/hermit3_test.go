@@ -229,3 +229,156 @@ func TestLengthFunctionMatchesMethod(t *testing.T) {
 		}
 	}
 }
+
+func TestTangentIsDerivative(t *testing.T) {
+	herm := T{
+		A: PointTangent{Point: vec3.T{0, 0, 0}, Tangent: vec3.T{1, 0.5, 0.2}},
+		B: PointTangent{Point: vec3.T{2, 1, 0.5}, Tangent: vec3.T{0.5, 1, 0.3}},
+	}
+	if got := herm.Tangent(1); !got.PracticallyEquals(&herm.B.Tangent, EPSILON) {
+		t.Errorf("Tangent(1) should equal B.Tangent: got %v, want %v", got, herm.B.Tangent)
+	}
+	// Compare the method and the function with central differences of Point
+	const h = 0.001
+	for _, tVal := range []float32{0.1, 0.25, 0.5, 0.75, 0.9} {
+		p0 := herm.Point(tVal - h)
+		p1 := herm.Point(tVal + h)
+		function := Tangent(&herm.A.Point, &herm.A.Tangent, &herm.B.Point, &herm.B.Tangent, tVal)
+		for _, tangent := range []vec3.T{herm.Tangent(tVal), function} {
+			for i := range tangent {
+				if diff := (p1[i] - p0[i]) / (2 * h); math.Abs(float64(tangent[i]-diff)) > 0.01 {
+					t.Errorf("Tangent(%f) is not the derivative: got %v, differences %f", tVal, tangent, diff)
+				}
+			}
+		}
+	}
+}
+
+func TestLengthAccuracy(t *testing.T) {
+	herm := T{
+		A: PointTangent{Point: vec3.T{0, 0, 0}, Tangent: vec3.T{1, 0.5, 0.2}},
+		B: PointTangent{Point: vec3.T{2, 1, 0.5}, Tangent: vec3.T{0.5, 1, 0.3}},
+	}
+	// Reference value from numerical integration with high precision
+	const want = 2.3101149319495664
+	if got := herm.Length(1); math.Abs(float64(got)-want) > EPSILON {
+		t.Errorf("length of hermit spline failed: got %f, want %f", got, want)
+	}
+	if got := herm.LengthEps(1, 1e-3); math.Abs(float64(got)-want) > 0.01 {
+		t.Errorf("length of hermit spline with low tolerance failed: got %f, want %f", got, want)
+	}
+}
+
+func TestArcLengthTable(t *testing.T) {
+	herm := T{
+		A: PointTangent{Point: vec3.T{0, 0, 0}, Tangent: vec3.T{1, 0.5, 0.2}},
+		B: PointTangent{Point: vec3.T{2, 1, 0.5}, Tangent: vec3.T{0.5, 1, 0.3}},
+	}
+	table := herm.ArcLengthTable(16)
+	params := table.UniformParams(4, nil)
+	for i, param := range params {
+		want := table.Length() * float32(i) / 3
+		if got := herm.Length(param); math.Abs(float64(got-want)) > EPSILON {
+			t.Errorf("uniform parameter %f has length %f, want %f", param, got, want)
+		}
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	herm := T{
+		A: PointTangent{Point: vec3.T{0, 0, 0}, Tangent: vec3.T{3, 4, -1}},
+		B: PointTangent{Point: vec3.T{2, 1, 1}, Tangent: vec3.T{-2, 3, 2}},
+	}
+	for _, tolerance := range []float32{0.1, 0.01, 0.001} {
+		points := herm.Flatten(tolerance, nil)
+		if points[0] != herm.A.Point || points[len(points)-1] != herm.B.Point {
+			t.Errorf("polyline does not start and end at the end points: %v", points)
+		}
+		for s := float32(0); s <= 1; s += 1.0 / 256 {
+			p := herm.Point(s)
+			if d := polylineDistance(points, &p); d > tolerance+0.0001 {
+				t.Errorf("point %v has distance %f from the polyline with tolerance %f", p, d, tolerance)
+			}
+		}
+	}
+}
+
+// polylineDistance returns the distance of p from the nearest segment of the polyline.
+func polylineDistance(points []vec3.T, p *vec3.T) float32 {
+	nearest := float32(-1)
+	for i := 1; i < len(points); i++ {
+		ab := vec3.Sub(&points[i], &points[i-1])
+		ap := vec3.Sub(p, &points[i-1])
+		s := float32(0)
+		if l := ab.LengthSqr(); l > 0 {
+			s = max(0, min(1, vec3.Dot(&ap, &ab)/l))
+		}
+		q := vec3.Interpolate(&points[i-1], &points[i], s)
+		if d := q.Sub(p).Length(); nearest < 0 || d < nearest {
+			nearest = d
+		}
+	}
+	return nearest
+}
+
+func TestFit(t *testing.T) {
+	herm := T{
+		A: PointTangent{Point: vec3.T{0, 0, 0}, Tangent: vec3.T{3, 3, 0}},
+		B: PointTangent{Point: vec3.T{2, 0, 1}, Tangent: vec3.T{3, -3, 1}},
+	}
+	points := make([]vec3.T, 40)
+	for i := range points {
+		points[i] = herm.Point(float32(i) / float32(len(points)-1))
+	}
+	splines := Fit(points, 0.001, math.Pi, nil)
+	if len(splines) == 0 || splines[0].A.Point != points[0] || splines[len(splines)-1].B.Point != points[len(points)-1] {
+		t.Fatalf("fitted curve does not start and end at the end points: %v", splines)
+	}
+	var curve []vec3.T
+	for i := range splines {
+		if i > 0 && splines[i].A.Point != splines[i-1].B.Point {
+			t.Errorf("spline %d does not start at the end of the previous spline", i)
+		}
+		curve = splines[i].Flatten(0.00001, curve)
+	}
+	for _, p := range points {
+		if d := polylineDistance(curve, &p); d > 0.001+EPSILON {
+			t.Errorf("point %v has distance %f from the fitted curve", p, d)
+		}
+	}
+	// The start direction is estimated from the first two points
+	if cos := vec3.Cosine(&splines[0].A.Tangent, &herm.A.Tangent); math.Abs(float64(cos)-1) > 0.001 {
+		t.Errorf("start tangent %v has not the direction of %v", splines[0].A.Tangent, herm.A.Tangent)
+	}
+}
+
+func TestBezierConversion(t *testing.T) {
+	herm := T{
+		A: PointTangent{Point: vec3.T{0, 0, 0}, Tangent: vec3.T{3, 3, 1}},
+		B: PointTangent{Point: vec3.T{2, 0, 1}, Tangent: vec3.T{3, -3, 0}},
+	}
+	bez := herm.Bezier()
+	for _, s := range []float32{0, 0.3, 0.5, 0.8, 1} {
+		if got, want := bez.Point(s), herm.Point(s); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("bezier point at %f failed: got %v, want %v", s, got, want)
+		}
+	}
+	back := FromBezier(&bez)
+	if !back.A.Point.PracticallyEquals(&herm.A.Point, EPSILON) || !back.A.Tangent.PracticallyEquals(&herm.A.Tangent, EPSILON) ||
+		!back.B.Point.PracticallyEquals(&herm.B.Point, EPSILON) || !back.B.Tangent.PracticallyEquals(&herm.B.Tangent, EPSILON) {
+		t.Errorf("conversion to bezier and back failed: got %v, want %v", back, herm)
+	}
+}
+
+func TestSecondDerivative(t *testing.T) {
+	herm := T{
+		A: PointTangent{Point: vec3.T{0, 0, 0}, Tangent: vec3.T{3, 3, 1}},
+		B: PointTangent{Point: vec3.T{2, 0, 1}, Tangent: vec3.T{3, -3, 0}},
+	}
+	bez := herm.Bezier()
+	for _, s := range []float32{0, 0.3, 0.5, 0.8, 1} {
+		if got, want := herm.SecondDerivative(s), bez.SecondDerivative(s); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("second derivative at %f failed: got %v, want %v", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Package hull3 contains a float32 type T for the convex hull of 3D points
+// made of triangles with their planes, computed with the quickhull algorithm.
+// See: C. Bradford Barber, David P. Dobkin, Hannu Huhdanpaa, The Quickhull Algorithm for Convex Hulls, 1996
+package hull3
+
+import (
+	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/vec3"
+)
+
+// machineEpsilon is the relative rounding error of float32.
+const machineEpsilon = 0x1p-23
+
+// Plane is the plane of all points p with Dot(Normal, p) == Distance.
+type Plane struct {
+	// Normal is the unit length normal of the plane.
+	Normal vec3.T
+	// Distance of the plane from the origin in the direction of Normal.
+	Distance float32
+}
+
+// SignedDistance returns the distance of p from the plane,
+// which is positive in the direction of the normal.
+func (plane *Plane) SignedDistance(p *vec3.T) float32 {
+	return vec3.Dot(&plane.Normal, p) - plane.Distance
+}
+
+// T is the convex hull of a set of points.
+type T struct {
+	// Points are the points of the hull.
+	Points []vec3.T
+	// Vertices are the indices of the points that are corners of the hull.
+	Vertices []int
+	// Triangles are index triples into Points, left winding seen from outside of the hull.
+	Triangles [][3]int
+	// Planes are the planes of the triangles with normals pointing outside.
+	Planes []Plane
+	// Box is the bounding box of the points.
+	Box vec3.Box
+
+	epsilon float32
+}
+
+// New returns the convex hull of points.
+// Points on the edges or triangles of the hull are not vertices of the hull.
+// If all points are coplanar within a tolerance relative to the coordinates,
+// the hull is flat with triangles for both sides,
+// if all points are collinear or equal the hull has no triangles.
+// Points is referenced by the result and must not be modified.
+func New(points []vec3.T) T {
+	hull := T{Points: points}
+	if len(points) == 0 {
+		return hull
+	}
+	hull.Box = vec3.Box{Min: points[0], Max: points[0]}
+	for i := range points {
+		hull.Box.Min = vec3.Min(&hull.Box.Min, &points[i])
+		hull.Box.Max = vec3.Max(&hull.Box.Max, &points[i])
+	}
+	// Tolerance for rounding errors proportional to the magnitude of the coordinates
+	low, high := hull.Box.Min.Absed(), hull.Box.Max.Absed()
+	extent := vec3.Max(&low, &high)
+	hull.epsilon = 3 * machineEpsilon * (extent[0] + extent[1] + extent[2])
+
+	b := builder{points: points, epsilon: hull.epsilon}
+	b.build(&hull.Box)
+	for _, f := range b.faces {
+		if !f.removed {
+			hull.Triangles = append(hull.Triangles, f.vertices)
+			hull.Planes = append(hull.Planes, f.plane)
+		}
+	}
+	used := make(map[int]bool)
+	for _, tri := range hull.Triangles {
+		for _, v := range tri {
+			if !used[v] {
+				used[v] = true
+				hull.Vertices = append(hull.Vertices, v)
+			}
+		}
+	}
+	return hull
+}
+
+// ContainsPoint returns if p is inside of the hull or on its border
+// within a tolerance relative to the coordinates of the points.
+// The bounding box is tested first for an early out.
+// A hull without triangles contains no points.
+func (hull *T) ContainsPoint(p *vec3.T) bool {
+	if len(hull.Planes) == 0 || !hull.Box.ContainsPoint(p) {
+		return false
+	}
+	for i := range hull.Planes {
+		if hull.Planes[i].SignedDistance(p) > hull.epsilon {
+			return false
+		}
+	}
+	return true
+}
+
+// Support returns the vertex of the hull that is farthest in direction,
+// which is the support function of collision detection algorithms like GJK.
+func (hull *T) Support(direction *vec3.T) vec3.T {
+	if len(hull.Vertices) == 0 {
+		if len(hull.Points) == 0 {
+			return vec3.Zero
+		}
+		// Hulls without triangles are supported by their points
+		best := 0
+		for i := range hull.Points {
+			if vec3.Dot(&hull.Points[i], direction) > vec3.Dot(&hull.Points[best], direction) {
+				best = i
+			}
+		}
+		return hull.Points[best]
+	}
+	best := hull.Vertices[0]
+	bestDot := vec3.Dot(&hull.Points[best], direction)
+	for _, v := range hull.Vertices[1:] {
+		if dot := vec3.Dot(&hull.Points[v], direction); dot > bestDot {
+			best, bestDot = v, dot
+		}
+	}
+	return hull.Points[best]
+}
+
+// Volume returns the volume enclosed by the hull.
+func (hull *T) Volume() float32 {
+	var volume float32
+	for _, tri := range hull.Triangles {
+		cross := vec3.Cross(&hull.Points[tri[1]], &hull.Points[tri[2]])
+		volume += vec3.Dot(&hull.Points[tri[0]], &cross)
+	}
+	return math.Abs(volume) / 6
+}
@@ -0,0 +1,148 @@
+package hull3
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ungerik/go3d/vec3"
+)
+
+const EPSILON = 0.0001
+
+func abs(x float32) float32 {
+	return float32(math.Abs(float64(x)))
+}
+
+// checkHull checks that the hull is closed and contains all points.
+func checkHull(t *testing.T, name string, hull *T) {
+	t.Helper()
+	edges := make(map[[2]int]bool)
+	for _, tri := range hull.Triangles {
+		for i := 0; i < 3; i++ {
+			edges[[2]int{tri[i], tri[(i+1)%3]}] = true
+		}
+	}
+	for edge := range edges {
+		if !edges[[2]int{edge[1], edge[0]}] {
+			t.Errorf("%s failed: edge %v has no twin", name, edge)
+		}
+	}
+	for i := range hull.Points {
+		if !hull.ContainsPoint(&hull.Points[i]) {
+			t.Errorf("%s failed: point %d is not contained", name, i)
+		}
+	}
+}
+
+func TestCube(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var points []vec3.T
+	for i := 0; i < 8; i++ {
+		points = append(points, vec3.T{float32(i & 1), float32(i >> 1 & 1), float32(i >> 2 & 1)})
+	}
+	// Points inside, on the faces and on the edges of the cube
+	for i := 0; i < 100; i++ {
+		p := vec3.T{rnd.Float32(), rnd.Float32(), rnd.Float32()}
+		if i%4 == 1 {
+			p[i%3] = 1
+		}
+		if i%4 == 2 {
+			p[0], p[1] = 0, 1
+		}
+		points = append(points, p)
+	}
+	hull := New(points)
+	checkHull(t, "cube", &hull)
+	if len(hull.Vertices) != 8 || len(hull.Triangles) != 12 {
+		t.Errorf("cube failed: got %d vertices and %d triangles, want 8 and 12", len(hull.Vertices), len(hull.Triangles))
+	}
+	if v := hull.Volume(); abs(v-1) > EPSILON {
+		t.Errorf("Volume failed: got %f, want 1", v)
+	}
+	for i, tri := range hull.Triangles {
+		// The centroid of the cube is behind all planes
+		center := vec3.T{0.5, 0.5, 0.5}
+		if d := hull.Planes[i].SignedDistance(&center); abs(d+0.5) > EPSILON {
+			t.Errorf("plane of triangle %v failed: got distance %f, want -0.5", tri, d)
+		}
+	}
+	if outside := (vec3.T{0.5, 0.5, 1.1}); hull.ContainsPoint(&outside) {
+		t.Errorf("ContainsPoint(%v) failed: got true, want false", outside)
+	}
+	direction := vec3.T{1, -1, 1}
+	if s, want := hull.Support(&direction), (vec3.T{1, 0, 1}); s != want {
+		t.Errorf("Support failed: got %v, want %v", s, want)
+	}
+}
+
+func TestSphere(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	points := make([]vec3.T, 500)
+	for i := range points {
+		p := vec3.T{rnd.Float32()*2 - 1, rnd.Float32()*2 - 1, rnd.Float32()*2 - 1}
+		p.Normalize()
+		points[i] = p
+	}
+	hull := New(points)
+	checkHull(t, "sphere", &hull)
+	// Euler's formula for closed triangle meshes
+	if len(hull.Triangles) != 2*len(hull.Vertices)-4 {
+		t.Errorf("sphere failed: got %d vertices and %d triangles", len(hull.Vertices), len(hull.Triangles))
+	}
+	if v := hull.Volume(); v > 4*math.Pi/3 || v < 4 {
+		t.Errorf("Volume failed: got %f", v)
+	}
+}
+
+func TestRotatedLattice(t *testing.T) {
+	// Many coplanar points on the faces of a rotated and sheared cube
+	var points []vec3.T
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			for z := 0; z < 8; z++ {
+				fx, fy, fz := float32(x), float32(y), float32(z)
+				points = append(points, vec3.T{0.6*fx - 0.8*fy, 0.8*fx + 0.6*fy, 1.1*fz + 0.3*fx})
+			}
+		}
+	}
+	hull := New(points)
+	checkHull(t, "rotated lattice", &hull)
+	if len(hull.Vertices) != 8 {
+		t.Errorf("rotated lattice failed: got %d vertices, want 8", len(hull.Vertices))
+	}
+	if v, want := hull.Volume(), float32(7*7*7*1.1); abs(v-want) > EPSILON*want {
+		t.Errorf("Volume failed: got %f, want %f", v, want)
+	}
+}
+
+func TestDegenerated(t *testing.T) {
+	square := []vec3.T{{0, 0, 1}, {2, 0, 1}, {2, 2, 1}, {0, 2, 1}, {1, 1, 1}, {1, 0, 1}}
+	hull := New(square)
+	checkHull(t, "square", &hull)
+	if len(hull.Vertices) != 4 || len(hull.Triangles) != 4 || hull.Volume() != 0 {
+		t.Errorf("square failed: got %d vertices and %d triangles", len(hull.Vertices), len(hull.Triangles))
+	}
+	for i, plane := range hull.Planes {
+		if abs(abs(plane.Normal[2])-1) > EPSILON || abs(plane.SignedDistance(&vec3.Zero)+plane.Normal[2]) > EPSILON {
+			t.Errorf("plane %d failed: got %v", i, plane)
+		}
+	}
+
+	line := New([]vec3.T{{0, 0, 0}, {1, 1, 1}, {2, 2, 2}})
+	if len(line.Triangles) != 0 || line.ContainsPoint(&vec3.Zero) {
+		t.Errorf("line failed: got %v", line.Triangles)
+	}
+	direction := vec3.T{1, 1, 0}
+	if s, want := line.Support(&direction), (vec3.T{2, 2, 2}); s != want {
+		t.Errorf("Support failed: got %v, want %v", s, want)
+	}
+
+	point := New([]vec3.T{{1, 2, 3}, {1, 2, 3}})
+	if len(point.Triangles) != 0 {
+		t.Errorf("point failed: got %v", point.Triangles)
+	}
+	if empty := New(nil); len(empty.Triangles) != 0 {
+		t.Errorf("no points failed: got %v", empty.Triangles)
+	}
+}
@@ -0,0 +1,76 @@
+package kochanek2
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/vec2"
+)
+
+const EPSILON = 0.0001
+
+func point(x, y float32) vec2.T {
+	var p vec2.T
+	p[0], p[1] = x, y
+	return p
+}
+
+var testPoints = []vec2.T{point(0, 0), point(1, 2), point(3, 2.5), point(4, 0), point(4.2, -3)}
+
+func TestInterpolatesPoints(t *testing.T) {
+	spline := T{Points: testPoints, Tension: 0.3, Continuity: -0.5, Bias: 0.2}
+	for i := range testPoints {
+		if got := spline.Point(float32(i)); !got.PracticallyEquals(&testPoints[i], EPSILON) {
+			t.Errorf("spline does not pass through point %d: got %v, want %v", i, got, testPoints[i])
+		}
+	}
+}
+
+func TestCatmullRom(t *testing.T) {
+	spline := T{Points: testPoints}
+	for i := 1; i < len(testPoints)-1; i++ {
+		want := vec2.Sub(&testPoints[i+1], &testPoints[i-1])
+		want.Scale(0.5)
+		if got := spline.Tangent(float32(i)); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("tangent at point %d failed: got %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestTension(t *testing.T) {
+	spline := T{Points: testPoints, Tension: 1}
+	for i := range testPoints {
+		if got := spline.Tangent(float32(i)); !got.PracticallyEquals(&vec2.Zero, EPSILON) {
+			t.Errorf("tangent with tension 1 at point %d should be zero, got %v", i, got)
+		}
+	}
+}
+
+func TestBiasAndContinuity(t *testing.T) {
+	// Full bias uses only the incoming direction
+	spline := T{Points: testPoints, Bias: 1}
+	want := vec2.Sub(&testPoints[2], &testPoints[1])
+	if got := spline.Tangent(2); !got.PracticallyEquals(&want, EPSILON) {
+		t.Errorf("tangent with bias 1 failed: got %v, want %v", got, want)
+	}
+
+	// Continuity other than 0 results in different incoming and outgoing tangents
+	spline = T{Points: testPoints, Continuity: 0.5}
+	in := spline.Hermit(1).B.Tangent
+	out := spline.Hermit(2).A.Tangent
+	if in.PracticallyEquals(&out, EPSILON) {
+		t.Errorf("incoming and outgoing tangents should differ with continuity 0.5: %v", in)
+	}
+}
+
+func TestBezier(t *testing.T) {
+	spline := T{Points: testPoints, Tension: -0.5, Bias: 0.3}
+	for i := 0; i < spline.Segments(); i++ {
+		bez := spline.Bezier(i)
+		for _, s := range []float32{0, 0.3, 0.5, 1} {
+			want := spline.Point(float32(i) + s)
+			if got := bez.Point(s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("bezier segment %d at %f failed: got %v, want %v", i, s, got, want)
+			}
+		}
+	}
+}
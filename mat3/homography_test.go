@@ -0,0 +1,118 @@
+package mat3
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/vec2"
+	"github.com/ungerik/go3d/vec3"
+)
+
+var testHomography = T{
+	vec3.T{1.2, 0.1, 0.001},
+	vec3.T{-0.2, 0.9, 0.002},
+	vec3.T{15, -4, 1},
+}
+
+func TestMulVec2Projective(t *testing.T) {
+	m := Ident
+	m.SetTranslation(&vec2.T{3, 4})
+	v := vec2.T{1, 2}
+	if got, want := m.MulVec2Projective(&v), (vec2.T{4, 6}); got != want {
+		t.Errorf("affine transform failed: got %v, want %v", got, want)
+	}
+	m[0][2] = 1 // w = x + 1
+	m.TransformVec2Projective(&v)
+	if want := (vec2.T{2, 3}); v != want {
+		t.Errorf("projective transform failed: got %v, want %v", v, want)
+	}
+}
+
+func TestHomography(t *testing.T) {
+	from := [4]vec2.T{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	to := [4]vec2.T{{10, 10}, {50, 20}, {45, 60}, {5, 40}}
+	h, err := Homography(&from, &to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range from {
+		p := h.MulVec2Projective(&from[i])
+		if !p.PracticallyEquals(&to[i], 0.001) {
+			t.Errorf("point %d mapped to %v, want %v", i, p, to[i])
+		}
+	}
+	if h[2][2] != 1 {
+		t.Errorf("homography not normalized: %v", h)
+	}
+
+	collinear := [4]vec2.T{{0, 0}, {1, 1}, {2, 2}, {0, 1}}
+	if _, err := Homography(&collinear, &to); err == nil {
+		t.Errorf("expected error for collinear points")
+	}
+}
+
+func TestHomographyDLT(t *testing.T) {
+	from := []vec2.T{{0, 0}, {100, 0}, {100, 80}, {0, 80}, {50, 40}, {20, 70}, {90, 10}}
+	to := make([]vec2.T, len(from))
+	for i := range from {
+		to[i] = testHomography.MulVec2Projective(&from[i])
+	}
+
+	h, err := HomographyDLT(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !h.PracticallyEquals(&testHomography, 0.001) {
+		t.Errorf("wrong homography: got %v, want %v", h, testHomography)
+	}
+
+	// Exactly four points must give the same result as Homography
+	var quadFrom, quadTo [4]vec2.T
+	copy(quadFrom[:], from)
+	copy(quadTo[:], to)
+	exact, _ := Homography(&quadFrom, &quadTo)
+	h, err = HomographyDLT(from[:4], to[:4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !h.PracticallyEquals(&exact, 0.001) {
+		t.Errorf("DLT of four points differs from exact solution: %v != %v", h, exact)
+	}
+}
+
+func TestHomographyDLTThin(t *testing.T) {
+	// A thin rectangle has small but non-zero singular values
+	quadFrom := [4]vec2.T{{0, 0}, {1, 0}, {1, 0.02}, {0, 0.02}}
+	quadTo := [4]vec2.T{{10, 10}, {50, 12}, {45, 40}, {8, 35}}
+	exact, err := Homography(&quadFrom, &quadTo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := append(quadFrom[:], vec2.T{0.5, 0.01})
+	to := append(quadTo[:], exact.MulVec2Projective(&from[4]))
+	for _, n := range []int{4, 5} {
+		h, err := HomographyDLT(from[:n], to[:n])
+		if err != nil {
+			t.Fatalf("DLT of thin rectangle with %d points failed: %v", n, err)
+		}
+		for i := range from {
+			p, q := h.MulVec2Projective(&from[i]), exact.MulVec2Projective(&from[i])
+			if !p.PracticallyEquals(&q, 0.01) {
+				t.Errorf("DLT of thin rectangle with %d points maps %v to %v, want %v", n, from[i], p, q)
+			}
+		}
+	}
+}
+
+func TestHomographyDLTErrors(t *testing.T) {
+	points := []vec2.T{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	if _, err := HomographyDLT(points[:3], points[:3]); err == nil {
+		t.Errorf("expected error for less than four points")
+	}
+	if _, err := HomographyDLT(points, points[:3]); err == nil {
+		t.Errorf("expected error for different lengths")
+	}
+	collinear := []vec2.T{{0, 0}, {1, 1}, {2, 2}, {3, 3}, {4, 4}}
+	if _, err := HomographyDLT(collinear, collinear); err == nil {
+		t.Errorf("expected error for collinear points")
+	}
+}
@@ -234,6 +234,8 @@ func (mat *T) TransformVec3(v *vec3.T) {
 }
 
 // Quaternion extracts a quaternion from the rotation part of the matrix.
+// The matrix has to be orthonormal, use Orthonormalized() first
+// to remove scaling or accumulated numerical drift.
 func (mat *T) Quaternion() quaternion.T {
 	tr := mat.Trace()
 
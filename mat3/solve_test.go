@@ -0,0 +1,135 @@
+package mat3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/vec3"
+)
+
+func TestLU(t *testing.T) {
+	lu, err := testMatrix2.LU()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, u, p := lu.L(), lu.U(), lu.P()
+	var pa, lu2 T
+	pa.AssignMul(&p, &testMatrix2)
+	lu2.AssignMul(&l, &u)
+	if !pa.PracticallyEquals(&lu2, EPSILON) {
+		t.Errorf("P*A != L*U: %v != %v", pa, lu2)
+	}
+	if det, expected := lu.Determinant(), testMatrix2.Determinant(); math.Abs(float64(det-expected)) > 0.01 {
+		t.Errorf("wrong determinant: got %f, want %f", det, expected)
+	}
+	inv := lu.Inverse()
+	expected, _ := testMatrix2.Inverted()
+	if !inv.PracticallyEquals(&expected, EPSILON) {
+		t.Errorf("wrong inverse: got %v, want %v", inv, expected)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	expected := vec3.T{1, -2, 3}
+	b := invertableMatrix1.MulVec3(&expected)
+	x, condition, err := invertableMatrix1.Solve(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !x.PracticallyEquals(&expected, EPSILON) {
+		t.Errorf("wrong solution: got %v, want %v", x, expected)
+	}
+	// 1-norm condition of invertableMatrix1 is |A|1 * |A^-1|1 = 16 * 15
+	if math.Abs(float64(condition-240)) > 0.01 {
+		t.Errorf("wrong condition number: got %f, want 240", condition)
+	}
+
+	if _, condition, err = Ident.Solve(&b); err != nil || condition != 1 {
+		t.Errorf("ident should have condition 1 and no error, got %f, %v", condition, err)
+	}
+}
+
+func TestSolveSingular(t *testing.T) {
+	b := vec3.T{1, 2, 3}
+	// Singular up to rounding errors
+	rounded := T{{0.1, 0.2, 0.3}, {0.4, 0.5, 0.6}, {0.7, 0.8, 0.9}}
+	for _, m := range []T{nonInvertableMatrix1, nonInvertableMatrix2, Zero, rounded} {
+		_, condition, err := m.Solve(&b)
+		if err != ErrSingular {
+			t.Errorf("expected ErrSingular for %v, got %v", m, err)
+		}
+		if !math.IsInf(float64(condition), 1) {
+			t.Errorf("expected infinite condition for %v, got %f", m, condition)
+		}
+	}
+}
+
+func TestQR(t *testing.T) {
+	for _, m := range []T{testMatrix2, invertableMatrix1, nonInvertableMatrix2, Ident} {
+		q, r := m.QR()
+		qt := q.Transposed()
+		var product T
+		product.AssignMul(&q, &qt)
+		if !product.PracticallyEquals(&Ident, EPSILON) {
+			t.Errorf("q of %v is not orthogonal: %v", m, q)
+		}
+		if r[0][1] != 0 || r[0][2] != 0 || r[1][2] != 0 {
+			t.Errorf("r of %v is not upper triangular: %v", m, r)
+		}
+		product.AssignMul(&q, &r)
+		if !product.PracticallyEquals(&m, 0.001) {
+			t.Errorf("q*r != m: %v != %v", product, m)
+		}
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	// Fit the plane z = 2x - y + 0.5 to exact samples
+	var rows []vec3.T
+	var b []float32
+	for x := float32(-2); x <= 2; x++ {
+		for y := float32(-1); y <= 1; y++ {
+			rows = append(rows, vec3.T{x, y, 1})
+			b = append(b, 2*x-y+0.5)
+		}
+	}
+	x, residual, err := LeastSquares(rows, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := vec3.T{2, -1, 0.5}
+	if !x.PracticallyEquals(&expected, EPSILON) {
+		t.Errorf("wrong solution: got %v, want %v", x, expected)
+	}
+	if residual > EPSILON {
+		t.Errorf("residual should be zero, got %f", residual)
+	}
+
+	// Inconsistent system: mean of the values is the least squares solution
+	rows = []vec3.T{{1, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	b = []float32{1, 3, 4, 5}
+	x, residual, err = LeastSquares(rows, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = vec3.T{2, 4, 5}
+	if !x.PracticallyEquals(&expected, EPSILON) {
+		t.Errorf("wrong solution: got %v, want %v", x, expected)
+	}
+	if math.Abs(float64(residual)-math.Sqrt2) > EPSILON {
+		t.Errorf("wrong residual: got %f, want %f", residual, math.Sqrt2)
+	}
+}
+
+func TestLeastSquaresErrors(t *testing.T) {
+	if _, _, err := LeastSquares([]vec3.T{{1, 0, 0}, {0, 1, 0}}, []float32{1, 2}); err != ErrSingular {
+		t.Errorf("expected ErrSingular for underdetermined system, got %v", err)
+	}
+	rankDeficient := []vec3.T{{1, 2, 3}, {2, 4, 6}, {1, 2, 3}, {3, 6, 9}}
+	if _, _, err := LeastSquares(rankDeficient, []float32{1, 2, 3, 4}); err != ErrSingular {
+		t.Errorf("expected ErrSingular for rank deficient system, got %v", err)
+	}
+	if _, _, err := LeastSquares([]vec3.T{{1, 0, 0}}, []float32{1, 2}); err == nil {
+		t.Errorf("expected error for mismatching lengths")
+	}
+}
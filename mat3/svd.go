@@ -0,0 +1,137 @@
+package mat3
+
+import (
+	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/vec3"
+)
+
+// machineEpsilon is the difference between 1 and the next representable float value.
+var machineEpsilon = math.Nextafter(1, 2) - 1
+
+// SVD computes the singular value decomposition mat = u * diag(s) * transpose(v)
+// using the one-sided Jacobi method.
+// The singular values in s are non-negative and sorted in descending order.
+// u and v are orthogonal matrices, but either one may contain a reflection.
+// For rank deficient matrices the columns of u belonging to zero singular values
+// are completed to an orthonormal basis.
+func (mat *T) SVD() (u T, s vec3.T, v T) {
+	a := *mat
+	v = Ident
+
+	for sweep := 0; sweep < maxJacobiSweeps; sweep++ {
+		rotated := false
+		for p := 0; p < 2; p++ {
+			for q := p + 1; q < 3; q++ {
+				alpha := a[p].LengthSqr()
+				beta := a[q].LengthSqr()
+				gamma := vec3.Dot(&a[p], &a[q])
+				if gamma == 0 || math.Abs(gamma) <= machineEpsilon*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				sn := c * t
+
+				for k := 0; k < 3; k++ {
+					apk := a[p][k]
+					aqk := a[q][k]
+					a[p][k] = c*apk - sn*aqk
+					a[q][k] = sn*apk + c*aqk
+
+					vpk := v[p][k]
+					vqk := v[q][k]
+					v[p][k] = c*vpk - sn*vqk
+					v[q][k] = sn*vpk + c*vqk
+				}
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+
+	s = vec3.T{a[0].Length(), a[1].Length(), a[2].Length()}
+
+	// Selection sort of three elements in descending order
+	for i := 0; i < 2; i++ {
+		max := i
+		for j := i + 1; j < 3; j++ {
+			if s[j] > s[max] {
+				max = j
+			}
+		}
+		if max != i {
+			s[i], s[max] = s[max], s[i]
+			a[i], a[max] = a[max], a[i]
+			v[i], v[max] = v[max], v[i]
+		}
+	}
+
+	// Singular values this small compared to the largest one carry no direction
+	threshold := 4 * machineEpsilon * s[0]
+	switch {
+	case s[0] == 0:
+		u = Ident
+	case s[1] <= threshold:
+		u[0] = a[0].Scaled(1 / s[0])
+		u[1] = u[0].Normal()
+		u[2] = vec3.Cross(&u[0], &u[1])
+	case s[2] <= threshold:
+		u[0] = a[0].Scaled(1 / s[0])
+		u[1] = a[1].Scaled(1 / s[1])
+		u[2] = vec3.Cross(&u[0], &u[1])
+	default:
+		u[0] = a[0].Scaled(1 / s[0])
+		u[1] = a[1].Scaled(1 / s[1])
+		u[2] = a[2].Scaled(1 / s[2])
+	}
+
+	return u, s, v
+}
+
+// Polar computes the polar decomposition mat = rotation * stretch,
+// where rotation is the closest proper rotation matrix to mat
+// and stretch is a symmetric matrix.
+// If mat is reflective, the reflection is moved into stretch
+// along the axis of the smallest singular value,
+// so rotation never contains a reflection.
+func (mat *T) Polar() (rotation, stretch T) {
+	u, s, v := mat.SVD()
+	if u.Determinant()*v.Determinant() < 0 {
+		u[2].Invert()
+		s[2] = -s[2]
+	}
+
+	vt := v.Transposed()
+	rotation.AssignMul(&u, &vt)
+
+	var sv T
+	sv[0] = v[0].Scaled(s[0])
+	sv[1] = v[1].Scaled(s[1])
+	sv[2] = v[2].Scaled(s[2])
+	stretch.AssignMul(&sv, &vt)
+
+	return rotation, stretch
+}
+
+// Orthonormalize replaces the matrix with the closest proper rotation matrix
+// by using the rotation part of the polar decomposition.
+// This removes scaling, shearing and accumulated numerical drift.
+func (mat *T) Orthonormalize() *T {
+	*mat, _ = mat.Polar()
+	return mat
+}
+
+// Orthonormalized returns the closest proper rotation matrix to the matrix.
+// See Orthonormalize().
+func (mat *T) Orthonormalized() T {
+	result := *mat
+	result.Orthonormalize()
+	return result
+}
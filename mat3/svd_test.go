@@ -0,0 +1,122 @@
+package mat3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/vec3"
+)
+
+func isRotation(mat *T) bool {
+	transposed := mat.Transposed()
+	var product T
+	product.AssignMul(mat, &transposed)
+	return product.PracticallyEquals(&Ident, EPSILON) && math.Abs(float64(mat.Determinant()-1)) <= EPSILON
+}
+
+func TestSVD(t *testing.T) {
+	matrices := []T{
+		testMatrix2,
+		invertableMatrix1,
+		nonInvertableMatrix1,
+		nonInvertableMatrix2,
+		Ident,
+		Zero,
+		{vec3.T{-1, 0, 0}, vec3.T{0, 1, 0}, vec3.T{0, 0, 1}},
+	}
+	for _, m := range matrices {
+		u, s, v := m.SVD()
+
+		if !(s[0] >= s[1] && s[1] >= s[2] && s[2] >= 0) {
+			t.Errorf("singular values of %v not sorted or negative: %v", m, s)
+		}
+		var product T
+		ut := u.Transposed()
+		product.AssignMul(&u, &ut)
+		if !product.PracticallyEquals(&Ident, EPSILON) {
+			t.Errorf("u of %v is not orthogonal: %v", m, u)
+		}
+		vt := v.Transposed()
+		product.AssignMul(&v, &vt)
+		if !product.PracticallyEquals(&Ident, EPSILON) {
+			t.Errorf("v of %v is not orthogonal: %v", m, v)
+		}
+
+		var us, reconstructed T
+		us[0] = u[0].Scaled(s[0])
+		us[1] = u[1].Scaled(s[1])
+		us[2] = u[2].Scaled(s[2])
+		reconstructed.AssignMul(&us, &vt)
+		if !reconstructed.PracticallyEquals(&m, 0.001) {
+			t.Errorf("u*s*vT != m: %v != %v", reconstructed, m)
+		}
+	}
+}
+
+func TestSVDSingularValues(t *testing.T) {
+	var rotation T
+	rotation.AssignEulerRotation(0.5, 0.2, -0.4)
+	scale := T{vec3.T{3, 0, 0}, vec3.T{0, 0.5, 0}, vec3.T{0, 0, 2}}
+	var m T
+	m.AssignMul(&rotation, &scale)
+
+	_, s, _ := m.SVD()
+	expected := vec3.T{3, 2, 0.5}
+	if !s.PracticallyEquals(&expected, EPSILON) {
+		t.Errorf("wrong singular values: got %v, want %v", s, expected)
+	}
+}
+
+func TestPolar(t *testing.T) {
+	var rotation T
+	rotation.AssignEulerRotation(1.2, -0.3, 0.8)
+	stretch := T{vec3.T{2, 0.5, 0}, vec3.T{0.5, 1, 0.25}, vec3.T{0, 0.25, 3}}
+	var m T
+	m.AssignMul(&rotation, &stretch)
+
+	r, s := m.Polar()
+	if !r.PracticallyEquals(&rotation, EPSILON) {
+		t.Errorf("wrong rotation: got %v, want %v", r, rotation)
+	}
+	if !s.PracticallyEquals(&stretch, 0.001) {
+		t.Errorf("wrong stretch: got %v, want %v", s, stretch)
+	}
+}
+
+func TestPolarReflective(t *testing.T) {
+	m := T{vec3.T{0, 1, 0}, vec3.T{1, 0, 0}, vec3.T{0, 0, 2}}
+	r, s := m.Polar()
+	if !isRotation(&r) {
+		t.Errorf("rotation contains reflection: %v", r)
+	}
+	var product T
+	product.AssignMul(&r, &s)
+	if !product.PracticallyEquals(&m, EPSILON) {
+		t.Errorf("rotation*stretch != m: %v != %v", product, m)
+	}
+}
+
+func TestOrthonormalized(t *testing.T) {
+	var rotation T
+	rotation.AssignEulerRotation(0.1, 0.7, -1.3)
+	drifted := rotation
+	drifted[0][1] += 0.01
+	drifted[1][2] -= 0.02
+	drifted[2][0] += 0.015
+	drifted.Mul(1.05)
+
+	orthonormal := drifted.Orthonormalized()
+	if !isRotation(&orthonormal) {
+		t.Errorf("result is not a rotation: %v", orthonormal)
+	}
+	if !orthonormal.PracticallyEquals(&rotation, 0.02) {
+		t.Errorf("result too far from original rotation: %v", orthonormal)
+	}
+
+	q := orthonormal.Quaternion()
+	var fromQuat T
+	fromQuat.AssignQuaternion(&q)
+	if !fromQuat.PracticallyEquals(&orthonormal, EPSILON) {
+		t.Errorf("quaternion roundtrip failed: %v != %v", fromQuat, orthonormal)
+	}
+}
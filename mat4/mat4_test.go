@@ -42,7 +42,7 @@ func TestDeterminant(t *testing.T) {
 		t.Errorf("Wrong determinant: %f", det)
 	}
 
-	scale2 := Ident.Scale(2)
+	scale2 := Ident.Scaled(2)
 	if det := scale2.Determinant(); det != 2*2*2*1 {
 		t.Errorf("Wrong determinant: %f", det)
 	}
@@ -0,0 +1,134 @@
+package mat4
+
+import (
+	"testing"
+
+	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/vec4"
+)
+
+var solveMatrix = T{
+	vec4.T{2, 1, 0, 4},
+	vec4.T{-1, 3, 2, 0},
+	vec4.T{0, 5, 1, 1},
+	vec4.T{3, 0, -2, 2},
+}
+
+func matricesEqual(a, b *T, allowedDelta float32) bool {
+	for col := range a {
+		if !vectorsEqual(&a[col], &b[col], allowedDelta) {
+			return false
+		}
+	}
+	return true
+}
+
+// vectorsEqual compares all four elements without homogeneous division.
+func vectorsEqual(a, b *vec4.T, allowedDelta float32) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > allowedDelta {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLU(t *testing.T) {
+	lu, err := solveMatrix.LU()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, u, p := lu.L(), lu.U(), lu.P()
+	var pa, lu2 T
+	pa.AssignMul(&p, &solveMatrix)
+	lu2.AssignMul(&l, &u)
+	if !matricesEqual(&pa, &lu2, EPSILON) {
+		t.Errorf("P*A != L*U: %v != %v", pa, lu2)
+	}
+	if det := lu.Determinant(); math.Abs(det-26) > 0.0001 {
+		t.Errorf("wrong determinant: got %f, want 26", det)
+	}
+	inv := lu.Inverse()
+	var product T
+	product.AssignMul(&solveMatrix, &inv)
+	if !matricesEqual(&product, &Ident, EPSILON) {
+		t.Errorf("A * inverse != ident: %v", product)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	expected := vec4.T{1, 2, -1, 0.5}
+	b := solveMatrix.MulVec4(&expected)
+	x, condition, err := solveMatrix.Solve(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !vectorsEqual(&x, &expected, EPSILON) {
+		t.Errorf("wrong solution: got %v, want %v", x, expected)
+	}
+	if condition < 1 || math.IsInf(condition, 1) {
+		t.Errorf("invalid condition number: %f", condition)
+	}
+
+	singular := solveMatrix
+	for row := 0; row < 4; row++ {
+		singular[3][row] = singular[0][row] + singular[1][row]
+	}
+	if _, _, err := singular.Solve(&b); err != ErrSingular {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+
+	// Singular up to rounding errors
+	for row := 0; row < 4; row++ {
+		singular[3][row] = 0.1*singular[0][row] + 0.7*singular[1][row]
+	}
+	if _, _, err := singular.Solve(&b); err != ErrSingular {
+		t.Errorf("expected ErrSingular for rounded singular matrix, got %v", err)
+	}
+}
+
+func TestQR(t *testing.T) {
+	for _, m := range []T{solveMatrix, Ident, Zero} {
+		q, r := m.QR()
+		qt := q
+		qt.Transpose()
+		var product T
+		product.AssignMul(&q, &qt)
+		if !matricesEqual(&product, &Ident, EPSILON) {
+			t.Errorf("q of %v is not orthogonal: %v", m, q)
+		}
+		for col := 0; col < 4; col++ {
+			for row := col + 1; row < 4; row++ {
+				if r[col][row] != 0 {
+					t.Errorf("r of %v is not upper triangular: %v", m, r)
+				}
+			}
+		}
+		product.AssignMul(&q, &r)
+		if !matricesEqual(&product, &m, 0.01) {
+			t.Errorf("q*r != m: %v != %v", product, m)
+		}
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	// Fit w = x + 2y - 3z + 4 to exact samples
+	var rows []vec4.T
+	var b []float32
+	for i := 0; i < 10; i++ {
+		x, y, z := float32(i%3), float32(i%4), float32(i%5)
+		rows = append(rows, vec4.T{x, y, z, 1})
+		b = append(b, x+2*y-3*z+4)
+	}
+	x, residual, err := LeastSquares(rows, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := vec4.T{1, 2, -3, 4}
+	if !vectorsEqual(&x, &expected, 0.001) {
+		t.Errorf("wrong solution: got %v, want %v", x, expected)
+	}
+	if residual > 0.001 {
+		t.Errorf("residual should be zero, got %f", residual)
+	}
+}
@@ -0,0 +1,76 @@
+package nurbs
+
+import (
+	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/vec3"
+	"github.com/ungerik/go3d/vec4"
+)
+
+// Conic returns the rational quadratic curve from p0 to p2 with the control point p1
+// and the weight w of p1, which is an exact conic section:
+// an ellipse for w < 1, a parabola for w = 1 and a hyperbola for w > 1.
+// The parameter domain of the curve is (0,1).
+func Conic(p0, p1, p2 *vec3.T, w float32) Curve {
+	return Curve{
+		Degree: 2,
+		ControlPoints: []vec4.T{
+			Homogeneous(p0, 1),
+			Homogeneous(p1, w),
+			Homogeneous(p2, 1),
+		},
+		Knots: []float32{0, 0, 0, 1, 1, 1},
+	}
+}
+
+// Arc returns an exact elliptical arc as rational quadratic curve
+// with the points center + cos(angle)*xAxis + sin(angle)*yAxis
+// for angle from startAngle to endAngle in radians.
+// For a circular arc xAxis and yAxis must be orthogonal and of the same length,
+// which is the radius.
+// The arc is split into segments of at most 90 degrees.
+// The parameter domain of the curve is (0,1), but the speed is not constant.
+func Arc(center, xAxis, yAxis *vec3.T, startAngle, endAngle float32) Curve {
+	sweep := endAngle - startAngle
+	segments := int(math.Ceil(math.Abs(sweep) / (math.Pi / 2)))
+	if segments < 1 {
+		segments = 1
+	}
+	delta := sweep / float32(segments)
+	// Weight of the middle control points and their distance factor from the center
+	w := math.Cos(delta / 2)
+
+	ellipsePoint := func(angle, scale float32) vec3.T {
+		x := xAxis.Scaled(math.Cos(angle) * scale)
+		y := yAxis.Scaled(math.Sin(angle) * scale)
+		return *x.Add(&y).Add(center)
+	}
+
+	curve := Curve{
+		Degree:        2,
+		ControlPoints: make([]vec4.T, 2*segments+1),
+		Knots:         make([]float32, 2*segments+4),
+	}
+	p := ellipsePoint(startAngle, 1)
+	curve.ControlPoints[0] = Homogeneous(&p, 1)
+	for i := 0; i < segments; i++ {
+		angle := startAngle + float32(i+1)*delta
+		// The middle control point is the intersection of the tangents at both ends
+		p1 := ellipsePoint(angle-delta/2, 1/w)
+		p2 := ellipsePoint(angle, 1)
+		curve.ControlPoints[2*i+1] = Homogeneous(&p1, w)
+		curve.ControlPoints[2*i+2] = Homogeneous(&p2, 1)
+	}
+
+	for i := range curve.Knots {
+		switch {
+		case i < 3:
+			curve.Knots[i] = 0
+		case i >= len(curve.Knots)-3:
+			curve.Knots[i] = 1
+		default:
+			// Double interior knots between the segments
+			curve.Knots[i] = float32((i-1)/2) / float32(segments)
+		}
+	}
+	return curve
+}
@@ -0,0 +1,141 @@
+package nurbs
+
+import (
+	"errors"
+
+	"github.com/ungerik/go3d/vec3"
+	"github.com/ungerik/go3d/vec4"
+)
+
+// Surface is a tensor product NURBS surface.
+// ControlPoints[i][j] is the control point i in U and j in V direction.
+// All rows must have the same length and the knot vectors
+// must match the number of control points and degree in their direction.
+type Surface struct {
+	DegreeU, DegreeV int
+	// ControlPoints holds the homogeneous control points (w*x, w*y, w*z, w).
+	ControlPoints  [][]vec4.T
+	KnotsU, KnotsV []float32
+}
+
+// NewSurface returns a NURBS surface with the control net points, their weights and knots.
+// If weights is nil, all weights are 1. If knotsU or knotsV is nil,
+// a clamped uniform knot vector is used, see ClampedKnots().
+// An error is returned if the dimensions of the arguments do not match.
+func NewSurface(degreeU, degreeV int, points [][]vec3.T, weights [][]float32, knotsU, knotsV []float32) (Surface, error) {
+	if len(points) == 0 {
+		return Surface{}, errors.New("surface has no control points")
+	}
+	if weights != nil && len(weights) != len(points) {
+		return Surface{}, errors.New("number of weights and points differ")
+	}
+	if knotsU == nil {
+		knotsU = ClampedKnots(degreeU, len(points))
+	}
+	if knotsV == nil {
+		knotsV = ClampedKnots(degreeV, len(points[0]))
+	}
+	surface := Surface{
+		DegreeU:       degreeU,
+		DegreeV:       degreeV,
+		ControlPoints: make([][]vec4.T, len(points)),
+		KnotsU:        knotsU,
+		KnotsV:        knotsV,
+	}
+	for i, row := range points {
+		if weights != nil && len(weights[i]) != len(row) {
+			return Surface{}, errors.New("number of weights and points differ")
+		}
+		surface.ControlPoints[i] = make([]vec4.T, len(row))
+		for j := range row {
+			w := float32(1)
+			if weights != nil {
+				w = weights[i][j]
+			}
+			surface.ControlPoints[i][j] = Homogeneous(&row[j], w)
+		}
+	}
+	return surface, surface.Validate()
+}
+
+// Validate returns an error if the degrees, control points or knots are not consistent.
+func (surface *Surface) Validate() error {
+	if len(surface.ControlPoints) == 0 {
+		return errors.New("surface has no control points")
+	}
+	if err := validate(surface.DegreeU, len(surface.ControlPoints), surface.KnotsU); err != nil {
+		return err
+	}
+	for _, row := range surface.ControlPoints {
+		if len(row) != len(surface.ControlPoints[0]) {
+			return errors.New("rows of control points differ in length")
+		}
+	}
+	return validate(surface.DegreeV, len(surface.ControlPoints[0]), surface.KnotsV)
+}
+
+// Domain returns the ranges of the surface parameters u and v.
+func (surface *Surface) Domain() (u0, u1, v0, v1 float32) {
+	countU := len(surface.ControlPoints)
+	countV := len(surface.ControlPoints[0])
+	return surface.KnotsU[surface.DegreeU], surface.KnotsU[countU],
+		surface.KnotsV[surface.DegreeV], surface.KnotsV[countV]
+}
+
+// HomogeneousPoint returns the homogeneous point of the surface at (u, v).
+// u and v are clamped to the Domain().
+func (surface *Surface) HomogeneousPoint(u, v float32) vec4.T {
+	column := make([]vec4.T, len(surface.ControlPoints))
+	for i, row := range surface.ControlPoints {
+		column[i] = deBoor(surface.DegreeV, row, surface.KnotsV, v)
+	}
+	return deBoor(surface.DegreeU, column, surface.KnotsU, u)
+}
+
+// Point returns the point of the surface at (u, v).
+// u and v are clamped to the Domain().
+func (surface *Surface) Point(u, v float32) vec3.T {
+	h := surface.HomogeneousPoint(u, v)
+	return h.Vec3DividedByW()
+}
+
+// Derivatives returns the point of the surface at (u, v)
+// and the partial derivatives with respect to u and v.
+// u and v are clamped to the Domain().
+func (surface *Surface) Derivatives(u, v float32) (point, du, dv vec3.T) {
+	column := make([]vec4.T, len(surface.ControlPoints))
+	columnV := make([]vec4.T, len(surface.ControlPoints))
+	for i, row := range surface.ControlPoints {
+		column[i] = deBoor(surface.DegreeV, row, surface.KnotsV, v)
+		degree, points, knots := derivativeCurve(surface.DegreeV, row, surface.KnotsV)
+		columnV[i] = deBoor(degree, points, knots, v)
+	}
+
+	a := deBoor(surface.DegreeU, column, surface.KnotsU, u)
+	degree, points, knots := derivativeCurve(surface.DegreeU, column, surface.KnotsU)
+	au := deBoor(degree, points, knots, u)
+	av := deBoor(surface.DegreeU, columnV, surface.KnotsU, u)
+
+	// S_u = (A_u - w_u*S) / w and S_v = (A_v - w_v*S) / w
+	w := a[3]
+	point = a.Vec3DividedByW()
+	du = au.Vec3()
+	wc := point.Scaled(au[3])
+	du.Sub(&wc).Scale(1 / w)
+	dv = av.Vec3()
+	wc = point.Scaled(av[3])
+	dv.Sub(&wc).Scale(1 / w)
+	return point, du, dv
+}
+
+// Normal returns the unit length normal of the surface at (u, v),
+// which is the normalized cross product of the partial derivatives.
+// Returns a zero vector where the surface is degenerated.
+func (surface *Surface) Normal(u, v float32) vec3.T {
+	_, du, dv := surface.Derivatives(u, v)
+	n := vec3.Cross(&du, &dv)
+	if n.IsZero() {
+		return vec3.Zero
+	}
+	return *n.Normalize()
+}
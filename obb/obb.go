@@ -0,0 +1,234 @@
+// Package obb contains a float32 type T for oriented bounding boxes
+// with intersection tests using the separating axis theorem.
+// See: Christer Ericson, Real-Time Collision Detection, 2005, chapter 4.4
+package obb
+
+import (
+	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/mat3"
+	"github.com/ungerik/go3d/mat4"
+	"github.com/ungerik/go3d/vec3"
+)
+
+// epsilon is added to the absolute rotation terms of the separating axis test
+// to avoid false separations for nearly parallel edges.
+const epsilon = 1e-6
+
+// T is a box with any orientation.
+type T struct {
+	// Center of the box.
+	Center vec3.T
+	// HalfExtents are the half lengths of the box along its axes.
+	HalfExtents vec3.T
+	// Rotation is the orthonormal matrix with the axes of the box as columns.
+	Rotation mat3.T
+}
+
+// FromBox returns the axis aligned box transformed by the affine matrix transform.
+// The transformation must be made of translation, rotation and scaling along the axes of box,
+// because a shear can't be represented by an oriented box.
+func FromBox(box *vec3.Box, transform *mat4.T) T {
+	center := box.Center()
+	diagonal := box.Diagonal()
+	var result T
+	result.Center = transform.MulVec3(&center)
+	for i := 0; i < 3; i++ {
+		axis := vec3.T{transform[i][0], transform[i][1], transform[i][2]}
+		length := axis.Length()
+		result.HalfExtents[i] = diagonal[i] / 2 * length
+		if length != 0 {
+			axis.Scale(1 / length)
+		}
+		result.Rotation[i] = axis
+	}
+	// Completes the axes of a scaling by zero and removes a reflection
+	result.Rotation.Orthonormalize()
+	return result
+}
+
+// FromPoints returns an oriented box containing all points
+// with the axes of the principal component analysis of the points.
+// This is fast, but not the minimal box, especially for unevenly distributed points.
+func FromPoints(points []vec3.T) T {
+	if len(points) == 0 {
+		return T{Rotation: mat3.Ident}
+	}
+	// The principal axes are the columns of a rotation matrix
+	mean, axes, _ := mat3.PrincipalComponents(points)
+
+	low := vec3.MaxVal
+	high := vec3.MinVal
+	for i := range points {
+		d := vec3.Sub(&points[i], &mean)
+		for axis := 0; axis < 3; axis++ {
+			distance := vec3.Dot(&d, &axes[axis])
+			low[axis] = min(low[axis], distance)
+			high[axis] = max(high[axis], distance)
+		}
+	}
+	result := T{Center: mean, Rotation: axes}
+	for axis := 0; axis < 3; axis++ {
+		offset := axes[axis].Scaled((low[axis] + high[axis]) / 2)
+		result.Center.Add(&offset)
+		result.HalfExtents[axis] = (high[axis] - low[axis]) / 2
+	}
+	return result
+}
+
+// Axis returns the unit length axis i of the box.
+func (obb *T) Axis(i int) vec3.T {
+	return obb.Rotation[i]
+}
+
+// Corners returns the eight corners of the box.
+func (obb *T) Corners() [8]vec3.T {
+	var corners [8]vec3.T
+	for i := range corners {
+		corners[i] = obb.Center
+		for axis := 0; axis < 3; axis++ {
+			extent := obb.HalfExtents[axis]
+			if i&(1<<axis) == 0 {
+				extent = -extent
+			}
+			offset := obb.Rotation[axis].Scaled(extent)
+			corners[i].Add(&offset)
+		}
+	}
+	return corners
+}
+
+// Box returns the axis aligned bounding box of the oriented box.
+func (obb *T) Box() vec3.Box {
+	var extent vec3.T
+	for axis := 0; axis < 3; axis++ {
+		a := obb.Rotation[axis].Absed()
+		a.Scale(obb.HalfExtents[axis])
+		extent.Add(&a)
+	}
+	return vec3.Box{Min: vec3.Sub(&obb.Center, &extent), Max: vec3.Add(&obb.Center, &extent)}
+}
+
+// Volume returns the volume of the box.
+func (obb *T) Volume() float32 {
+	return 8 * obb.HalfExtents[0] * obb.HalfExtents[1] * obb.HalfExtents[2]
+}
+
+// Local returns p in the coordinate system of the box
+// with the center at the origin and the axes of the box as coordinate axes.
+func (obb *T) Local(p *vec3.T) vec3.T {
+	d := vec3.Sub(p, &obb.Center)
+	return vec3.T{
+		vec3.Dot(&d, &obb.Rotation[0]),
+		vec3.Dot(&d, &obb.Rotation[1]),
+		vec3.Dot(&d, &obb.Rotation[2]),
+	}
+}
+
+// ContainsPoint returns if p is inside or on the surface of the box.
+func (obb *T) ContainsPoint(p *vec3.T) bool {
+	local := obb.Local(p)
+	return math.Abs(local[0]) <= obb.HalfExtents[0] &&
+		math.Abs(local[1]) <= obb.HalfExtents[1] &&
+		math.Abs(local[2]) <= obb.HalfExtents[2]
+}
+
+// ClosestPoint returns the point inside or on the surface of the box
+// that is closest to p.
+func (obb *T) ClosestPoint(p *vec3.T) vec3.T {
+	local := obb.Local(p)
+	result := obb.Center
+	for axis := 0; axis < 3; axis++ {
+		extent := obb.HalfExtents[axis]
+		offset := obb.Rotation[axis].Scaled(min(max(local[axis], -extent), extent))
+		result.Add(&offset)
+	}
+	return result
+}
+
+// Intersects returns if the box and other overlap or touch
+// by testing the 15 possible separating axes.
+func (obb *T) Intersects(other *T) bool {
+	a, b := &obb.HalfExtents, &other.HalfExtents
+	// Rotation of other in the coordinate system of obb
+	var r, absR [3][3]float32
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i][j] = vec3.Dot(&obb.Rotation[i], &other.Rotation[j])
+			absR[i][j] = math.Abs(r[i][j]) + epsilon
+		}
+	}
+	t := obb.Local(&other.Center)
+
+	// Axes of obb
+	for i := 0; i < 3; i++ {
+		rb := b[0]*absR[i][0] + b[1]*absR[i][1] + b[2]*absR[i][2]
+		if math.Abs(t[i]) > a[i]+rb {
+			return false
+		}
+	}
+	// Axes of other
+	for j := 0; j < 3; j++ {
+		ra := a[0]*absR[0][j] + a[1]*absR[1][j] + a[2]*absR[2][j]
+		if math.Abs(t[0]*r[0][j]+t[1]*r[1][j]+t[2]*r[2][j]) > ra+b[j] {
+			return false
+		}
+	}
+	// Cross products of the axes of obb and other
+	for i := 0; i < 3; i++ {
+		i1, i2 := (i+1)%3, (i+2)%3
+		for j := 0; j < 3; j++ {
+			j1, j2 := (j+1)%3, (j+2)%3
+			ra := a[i1]*absR[i2][j] + a[i2]*absR[i1][j]
+			rb := b[j1]*absR[i][j2] + b[j2]*absR[i][j1]
+			if math.Abs(t[i2]*r[i1][j]-t[i1]*r[i2][j]) > ra+rb {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IntersectsBox returns if the oriented box and the axis aligned box overlap or touch.
+func (obb *T) IntersectsBox(box *vec3.Box) bool {
+	diagonal := box.Diagonal()
+	aligned := T{
+		Center:      box.Center(),
+		HalfExtents: diagonal.Scaled(0.5),
+		Rotation:    mat3.Ident,
+	}
+	return obb.Intersects(&aligned)
+}
+
+// IntersectRay returns if the ray from origin in direction hits the box
+// and the distance to the first intersection in units of the length of direction.
+// The distance is zero if origin is inside of the box.
+func (obb *T) IntersectRay(origin, direction *vec3.T) (distance float32, hit bool) {
+	o := obb.Local(origin)
+	d := vec3.T{
+		vec3.Dot(direction, &obb.Rotation[0]),
+		vec3.Dot(direction, &obb.Rotation[1]),
+		vec3.Dot(direction, &obb.Rotation[2]),
+	}
+	near, far := float32(0), math.Inf(1)
+	for axis := 0; axis < 3; axis++ {
+		extent := obb.HalfExtents[axis]
+		if d[axis] == 0 {
+			// Parallel to the slab
+			if math.Abs(o[axis]) > extent {
+				return 0, false
+			}
+			continue
+		}
+		t1 := (-extent - o[axis]) / d[axis]
+		t2 := (extent - o[axis]) / d[axis]
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		near = max(near, t1)
+		far = min(far, t2)
+		if near > far {
+			return 0, false
+		}
+	}
+	return near, true
+}
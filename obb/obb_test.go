@@ -0,0 +1,166 @@
+package obb
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/mat3"
+	"github.com/ungerik/go3d/mat4"
+	"github.com/ungerik/go3d/vec3"
+)
+
+const EPSILON = 0.0001
+
+func abs(x float32) float32 {
+	return float32(math.Abs(float64(x)))
+}
+
+// rotatedZ returns a box with the half extents rotated around the Z axis by angle.
+func rotatedZ(center, halfExtents vec3.T, angle float32) T {
+	var rotation mat3.T
+	rotation.AssignZRotation(angle)
+	return T{Center: center, HalfExtents: halfExtents, Rotation: rotation}
+}
+
+func TestFromPoints(t *testing.T) {
+	// Points of a box with the size 8 x 2 x 1 rotated around the Z axis
+	want := rotatedZ(vec3.T{1, 2, 3}, vec3.T{4, 1, 0.5}, 0.5)
+	// A symmetric grid, so that the principal axes are the axes of the box
+	var points []vec3.T
+	for x := -4; x <= 4; x++ {
+		for y := -2; y <= 2; y++ {
+			for z := -2; z <= 2; z++ {
+				local := vec3.T{float32(x), float32(y) / 2, float32(z) / 4}
+				p := want.Center
+				for axis := 0; axis < 3; axis++ {
+					offset := want.Rotation[axis].Scaled(local[axis])
+					p.Add(&offset)
+				}
+				points = append(points, p)
+			}
+		}
+	}
+	obb := FromPoints(points)
+	if vec3.Distance(&obb.Center, &want.Center) > EPSILON || vec3.Distance(&obb.HalfExtents, &want.HalfExtents) > EPSILON {
+		t.Errorf("FromPoints failed: got %v, want %v", obb, want)
+	}
+	if obb.Rotation.Determinant() < 0 {
+		t.Errorf("FromPoints failed: rotation %v is reflective", obb.Rotation)
+	}
+	for i := range points {
+		local := obb.Local(&points[i])
+		for axis := 0; axis < 3; axis++ {
+			if abs(local[axis]) > obb.HalfExtents[axis]+EPSILON {
+				t.Errorf("FromPoints failed: point %v is outside", points[i])
+			}
+		}
+	}
+
+	if single := FromPoints([]vec3.T{{1, 2, 3}}); single.Center != (vec3.T{1, 2, 3}) || single.HalfExtents != (vec3.T{}) {
+		t.Errorf("FromPoints of single point failed: got %v", single)
+	}
+}
+
+func TestFromBox(t *testing.T) {
+	box := vec3.Box{Min: vec3.T{-1, -1, -1}, Max: vec3.T{3, 1, 1}}
+	// Rotation around the Z axis by 90 degrees with the scaling (1, 2, 1) and a translation
+	transform := mat4.T{
+		{0, 1, 0, 0},
+		{-2, 0, 0, 0},
+		{0, 0, 1, 0},
+		{5, 0, 0, 1},
+	}
+	obb := FromBox(&box, &transform)
+	corners := obb.Corners()
+	for _, corner := range []vec3.T{{-1, -1, -1}, {3, 1, 1}, {3, -1, 1}} {
+		transformed := transform.MulVec3(&corner)
+		found := false
+		for i := range corners {
+			found = found || vec3.Distance(&corners[i], &transformed) < EPSILON
+		}
+		if !found {
+			t.Errorf("FromBox failed: transformed corner %v is not a corner of %v", transformed, corners)
+		}
+	}
+	if abs(obb.Volume()-32) > EPSILON {
+		t.Errorf("FromBox failed: got volume %f, want 32", obb.Volume())
+	}
+}
+
+func TestContainsPoint(t *testing.T) {
+	obb := rotatedZ(vec3.T{0, 0, 0}, vec3.T{2, 1, 1}, math.Pi/4)
+	inside := vec3.T{1.2, 1.2, 0}
+	outside := vec3.T{1, -1, 0}
+	if !obb.ContainsPoint(&inside) || obb.ContainsPoint(&outside) {
+		t.Errorf("ContainsPoint failed")
+	}
+	closest := obb.ClosestPoint(&outside)
+	want := vec3.T{1 / float32(math.Sqrt2), -1 / float32(math.Sqrt2), 0}
+	if vec3.Distance(&closest, &want) > EPSILON {
+		t.Errorf("ClosestPoint failed: got %v, want %v", closest, want)
+	}
+	if closest := obb.ClosestPoint(&inside); vec3.Distance(&closest, &inside) > EPSILON {
+		t.Errorf("ClosestPoint of inside point failed: got %v", closest)
+	}
+	box := obb.Box()
+	extent := 3 / float32(math.Sqrt2)
+	if abs(box.Max[0]-extent) > EPSILON || abs(box.Max[1]-extent) > EPSILON || abs(box.Max[2]-1) > EPSILON {
+		t.Errorf("Box failed: got %v", box)
+	}
+}
+
+func TestIntersects(t *testing.T) {
+	a := rotatedZ(vec3.T{0, 0, 0}, vec3.T{2, 1, 1}, math.Pi/4)
+	// Separated only by the diagonal axis of a
+	b := rotatedZ(vec3.T{2, -1, 0}, vec3.T{0.5, 0.5, 0.5}, 0)
+	if a.Intersects(&b) || b.Intersects(&a) {
+		t.Errorf("Intersects failed for separated boxes")
+	}
+	c := rotatedZ(vec3.T{1.5, 1.5, 0}, vec3.T{0.5, 0.5, 0.5}, 0.3)
+	if !a.Intersects(&c) || !c.Intersects(&a) {
+		t.Errorf("Intersects failed for overlapping boxes")
+	}
+	if !a.Intersects(&a) {
+		t.Errorf("Intersects failed for identical boxes")
+	}
+
+	// Edges crossing each other, separated only by the cross product of the edges
+	var rx, ry mat3.T
+	rx.AssignXRotation(math.Pi / 4)
+	ry.AssignYRotation(math.Pi / 4)
+	d := T{HalfExtents: vec3.T{1, 1, 1}, Rotation: rx}
+	e := T{Center: vec3.T{0, 0, 2.7}, HalfExtents: vec3.T{1, 1, 1}, Rotation: ry}
+	if !d.Intersects(&e) {
+		t.Errorf("Intersects failed for boxes with crossed edges")
+	}
+	// The edges touch at the distance 2 * Sqrt2
+	e.Center[2] = 2.9
+	if d.Intersects(&e) {
+		t.Errorf("Intersects failed for boxes separated by crossed edges")
+	}
+
+	box := vec3.Box{Min: vec3.T{1.8, -2, -1}, Max: vec3.T{3, -0.5, 1}}
+	overlapping := vec3.Box{Min: vec3.T{0.5, -2, -1}, Max: vec3.T{3, -0.5, 1}}
+	if a.IntersectsBox(&box) || !a.IntersectsBox(&overlapping) {
+		t.Errorf("IntersectsBox failed")
+	}
+}
+
+func TestIntersectRay(t *testing.T) {
+	obb := rotatedZ(vec3.T{5, 0, 0}, vec3.T{1, 1, 1}, math.Pi/4)
+	origin := vec3.T{0, 0, 0}
+	if distance, hit := obb.IntersectRay(&origin, &vec3.UnitX); !hit || abs(distance-(5-float32(math.Sqrt2))) > EPSILON {
+		t.Errorf("IntersectRay failed: got %f, %v", distance, hit)
+	}
+	if _, hit := obb.IntersectRay(&origin, &vec3.UnitY); hit {
+		t.Errorf("IntersectRay failed for ray that misses")
+	}
+	behind := vec3.T{-1, 0, 0}
+	if _, hit := obb.IntersectRay(&origin, &behind); hit {
+		t.Errorf("IntersectRay failed for box behind the ray")
+	}
+	inside := vec3.T{5, 0, 0}
+	if distance, hit := obb.IntersectRay(&inside, &vec3.UnitZ); !hit || distance != 0 {
+		t.Errorf("IntersectRay failed for origin inside: got %f, %v", distance, hit)
+	}
+}
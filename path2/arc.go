@@ -0,0 +1,95 @@
+package path2
+
+import (
+	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/vec2"
+)
+
+// ArcTo adds an elliptical arc from the current point to p with the semantics
+// of the SVG arc command, approximated by cubic bezier splines of at most 90 degrees.
+// radius holds the radii of the ellipse in x and y direction before the ellipse
+// is rotated by rotation (radians). Radii that are too small to reach p are scaled up.
+// Of the four possible arcs, largeArc selects one of more than 180 degrees
+// and sweep one that runs in the direction of increasing angles.
+// A zero radius results in a line, an arc to the current point is omitted.
+// See: https://www.w3.org/TR/SVG/implnote.html#ArcImplementationNotes
+func (path *T) ArcTo(radius *vec2.T, rotation float32, largeArc, sweep bool, p *vec2.T) *T {
+	start := path.CurrentPoint()
+	if start == *p {
+		return path
+	}
+	rx, ry := math.Abs(radius[0]), math.Abs(radius[1])
+	if rx == 0 || ry == 0 {
+		return path.LineTo(p)
+	}
+	sin, cos := math.Sincos(rotation)
+
+	// Half of the chord in the coordinate system of the ellipse
+	hx := (start[0] - p[0]) / 2
+	hy := (start[1] - p[1]) / 2
+	x1 := cos*hx + sin*hy
+	y1 := -sin*hx + cos*hy
+
+	if lambda := (x1*x1)/(rx*rx) + (y1*y1)/(ry*ry); lambda > 1 {
+		s := math.Sqrt(lambda)
+		rx *= s
+		ry *= s
+	}
+
+	// Center of the ellipse
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := math.Sqrt(max(0, num/den))
+	if largeArc == sweep {
+		coef = -coef
+	}
+	cx1 := coef * rx * y1 / ry
+	cy1 := -coef * ry * x1 / rx
+	center := vec2.T{
+		cos*cx1 - sin*cy1 + (start[0]+p[0])/2,
+		sin*cx1 + cos*cy1 + (start[1]+p[1])/2,
+	}
+
+	startAngle := math.Atan2((y1-cy1)/ry, (x1-cx1)/rx)
+	endAngle := math.Atan2((-y1-cy1)/ry, (-x1-cx1)/rx)
+	sweepAngle := endAngle - startAngle
+	if sweep && sweepAngle < 0 {
+		sweepAngle += 2 * math.Pi
+	} else if !sweep && sweepAngle > 0 {
+		sweepAngle -= 2 * math.Pi
+	}
+
+	// Point and derivative of the ellipse at an angle
+	ellipse := func(angle float32) (point, tangent vec2.T) {
+		s, c := math.Sincos(angle)
+		point = vec2.T{
+			center[0] + cos*rx*c - sin*ry*s,
+			center[1] + sin*rx*c + cos*ry*s,
+		}
+		tangent = vec2.T{
+			-cos*rx*s - sin*ry*c,
+			-sin*rx*s + cos*ry*c,
+		}
+		return point, tangent
+	}
+
+	// Allow for rounding errors of quarter arcs
+	segments := max(1, int(math.Ceil(math.Abs(sweepAngle)/(math.Pi/2)-0.001)))
+	delta := sweepAngle / float32(segments)
+	// Length of the control point tangents for a bezier spline approximating a circular arc
+	k := 4.0 / 3.0 * math.Tan(delta/4)
+	p0, t0 := ellipse(startAngle)
+	for i := 1; i <= segments; i++ {
+		p3, t3 := ellipse(startAngle + float32(i)*delta)
+		if i == segments {
+			p3 = *p
+		}
+		c1 := t0.Scaled(k)
+		c1.Add(&p0)
+		c2 := t3.Scaled(-k)
+		c2.Add(&p3)
+		path.CubicTo(&c1, &c2, &p3)
+		p0, t0 = p3, t3
+	}
+	return path
+}
@@ -0,0 +1,109 @@
+package path2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/vec2"
+)
+
+func TestBoundingBox(t *testing.T) {
+	path := mustParse(t, "M1 1 C1 5 5 5 5 1 Q7 -1 9 1")
+	rect := path.BoundingBox()
+	want := vec2.Rect{Min: vec2.T{1, 0}, Max: vec2.T{9, 4}}
+	if !rect.Min.PracticallyEquals(&want.Min, EPSILON) || !rect.Max.PracticallyEquals(&want.Max, EPSILON) {
+		t.Errorf("bounding box failed: got %v, want %v", rect, want)
+	}
+	path = mustParse(t, "M2 3 M-1 5")
+	if rect := path.BoundingBox(); rect.Min != (vec2.T{-1, 3}) || rect.Max != (vec2.T{2, 5}) {
+		t.Errorf("bounding box of moves failed: got %v", rect)
+	}
+	var empty T
+	if rect := empty.BoundingBox(); rect != (vec2.Rect{}) {
+		t.Errorf("bounding box of empty path should be zero, got %v", rect)
+	}
+}
+
+func TestLength(t *testing.T) {
+	square := mustParse(t, "M0 0 H10 V10 H0 Z M20 20")
+	if got := square.Length(); math.Abs(float64(got-40)) > EPSILON {
+		t.Errorf("length of square failed: got %f, want 40", got)
+	}
+	circle := mustParse(t, "M10 0 A10 10 0 0 1 -10 0 A10 10 0 0 1 10 0")
+	if got, want := float64(circle.Length()), 20*math.Pi; math.Abs(got-want) > 0.01 {
+		t.Errorf("length of circle failed: got %f, want %f", got, want)
+	}
+	quad := mustParse(t, "M0 0 Q1 0 2 0")
+	if got := quad.Length(); math.Abs(float64(got-2)) > EPSILON {
+		t.Errorf("length of straight quad failed: got %f, want 2", got)
+	}
+}
+
+func TestPointAtDistance(t *testing.T) {
+	square := mustParse(t, "M0 0 H10 V10 H0 Z")
+	for _, test := range []struct {
+		distance   float32
+		point, dir vec2.T
+	}{
+		{-1, vec2.T{0, 0}, vec2.T{1, 0}},
+		{0, vec2.T{0, 0}, vec2.T{1, 0}},
+		{5, vec2.T{5, 0}, vec2.T{1, 0}},
+		{15, vec2.T{10, 5}, vec2.T{0, 1}},
+		{35, vec2.T{0, 5}, vec2.T{0, -1}},
+		{50, vec2.T{0, 0}, vec2.T{0, -1}},
+	} {
+		point, dir := square.PointAtDistance(test.distance)
+		if !point.PracticallyEquals(&test.point, EPSILON) || !dir.PracticallyEquals(&test.dir, EPSILON) {
+			t.Errorf("point at distance %f failed: got %v %v, want %v %v", test.distance, point, dir, test.point, test.dir)
+		}
+	}
+
+	circle := mustParse(t, "M10 0 A10 10 0 0 1 -10 0 A10 10 0 0 1 10 0")
+	for _, angle := range []float64{0.3, 1, 2.5, 4, 6} {
+		point, dir := circle.PointAtDistance(float32(10 * angle))
+		want := vec2.T{float32(10 * math.Cos(angle)), float32(10 * math.Sin(angle))}
+		wantDir := vec2.T{float32(-math.Sin(angle)), float32(math.Cos(angle))}
+		if !point.PracticallyEquals(&want, 0.01) || !dir.PracticallyEquals(&wantDir, 0.01) {
+			t.Errorf("point on circle at angle %f failed: got %v %v, want %v %v", angle, point, dir, want, wantDir)
+		}
+	}
+
+	// Control points that coincide with the start have a zero derivative,
+	// the direction of the chord is used instead
+	for _, data := range []string{"M0 0 S10 10 20 0", "M0 0 Q0 0 20 0"} {
+		path := mustParse(t, data)
+		want := vec2.T{1, 0}
+		if point, dir := path.PointAtDistance(0); point != vec2.Zero || !dir.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("point at distance 0 of %q failed: got %v %v, want %v %v", data, point, dir, vec2.Zero, want)
+		}
+	}
+
+	move := mustParse(t, "M3 4")
+	if point, dir := move.PointAtDistance(1); point != (vec2.T{3, 4}) || dir != vec2.Zero {
+		t.Errorf("point of path without length failed: got %v %v", point, dir)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	path := mustParse(t, "M0 0 H10 V10 H0 Z L5 -5 M20 0 C20 10 30 10 30 0")
+	lines := path.Flatten(0.01, nil)
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 polylines, got %v", lines)
+	}
+	square := lines[0]
+	if !square.Closed || len(square.Points) != 4 || square.Points[3] != (vec2.T{0, 10}) {
+		t.Errorf("closed square failed: %v", square)
+	}
+	if lines[1].Closed || len(lines[1].Points) != 2 || lines[1].Points[0] != vec2.Zero {
+		t.Errorf("line after close should start at the start of the closed sub-path: %v", lines[1])
+	}
+	curve := lines[2]
+	if curve.Closed || curve.Points[0] != (vec2.T{20, 0}) || curve.Points[len(curve.Points)-1] != (vec2.T{30, 0}) || len(curve.Points) < 8 {
+		t.Errorf("flattened curve failed: %v", curve)
+	}
+	for i := 1; i < len(curve.Points); i++ {
+		if curve.Points[i] == curve.Points[i-1] {
+			t.Errorf("flattened curve has duplicate point %v", curve.Points[i])
+		}
+	}
+}
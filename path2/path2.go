@@ -0,0 +1,140 @@
+// Package path2 contains a float32 type T for 2D paths
+// made of lines, quadratic and cubic bezier splines and elliptical arcs,
+// which can be parsed from and formatted as SVG path data,
+// measured, flattened to polylines and stroked to outline polygons.
+// See: https://www.w3.org/TR/SVG/paths.html
+package path2
+
+import (
+	"github.com/ungerik/go3d/bezier2"
+	"github.com/ungerik/go3d/qbezier2"
+	"github.com/ungerik/go3d/vec2"
+)
+
+// Command is the kind of a path segment.
+// Its value is the letter of the absolute SVG path command.
+type Command byte
+
+const (
+	// Move starts a new sub-path at Points[0].
+	Move Command = 'M'
+	// Line draws a line to Points[0].
+	Line Command = 'L'
+	// Quad draws a quadratic bezier spline with the control point Points[0] to Points[1].
+	Quad Command = 'Q'
+	// Cubic draws a cubic bezier spline with the control points Points[0] and Points[1] to Points[2].
+	Cubic Command = 'C'
+	// ClosePath draws a line back to the start of the sub-path, which is stored in Points[0].
+	ClosePath Command = 'Z'
+)
+
+// Segment is a command of a path with its points.
+// Every segment starts at the end point of the previous segment.
+type Segment struct {
+	Command Command
+	Points  [3]vec2.T
+}
+
+// End returns the end point of the segment,
+// which is the start point of the next segment.
+func (seg *Segment) End() vec2.T {
+	switch seg.Command {
+	case Quad:
+		return seg.Points[1]
+	case Cubic:
+		return seg.Points[2]
+	default:
+		return seg.Points[0]
+	}
+}
+
+// Quadratic returns the quadratic bezier spline of a Quad segment starting at start.
+// Line and ClosePath segments are returned as straight quadratic splines.
+// Cubic segments can't be represented exactly, use qbezier2.FromCubic() with Bezier() for them.
+func (seg *Segment) Quadratic(start *vec2.T) qbezier2.T {
+	if seg.Command == Quad {
+		return qbezier2.T{P0: *start, P1: seg.Points[0], P2: seg.Points[1]}
+	}
+	end := seg.End()
+	return qbezier2.T{P0: *start, P1: vec2.Interpolate(start, &end, 0.5), P2: end}
+}
+
+// Bezier returns the cubic bezier spline that describes the segment starting at start exactly.
+// Line and ClosePath segments are returned as straight splines,
+// Quad segments are elevated to cubic splines.
+func (seg *Segment) Bezier(start *vec2.T) bezier2.T {
+	switch seg.Command {
+	case Quad:
+		quad := seg.Quadratic(start)
+		return quad.Cubic()
+	case Cubic:
+		return bezier2.T{P0: *start, P1: seg.Points[0], P2: seg.Points[1], P3: seg.Points[2]}
+	default:
+		end := seg.End()
+		return bezier2.T{
+			P0: *start,
+			P1: vec2.Interpolate(start, &end, 1.0/3.0),
+			P2: vec2.Interpolate(start, &end, 2.0/3.0),
+			P3: end,
+		}
+	}
+}
+
+// T is a 2D path made of segments.
+// It consists of one or more sub-paths, each started by a Move segment.
+type T struct {
+	Segments []Segment
+}
+
+// CurrentPoint returns the end point of the last segment,
+// which is the start point of the next segment, or (0,0) for an empty path.
+func (path *T) CurrentPoint() vec2.T {
+	if len(path.Segments) == 0 {
+		return vec2.Zero
+	}
+	return path.Segments[len(path.Segments)-1].End()
+}
+
+// subPathStart returns the start point of the current sub-path.
+func (path *T) subPathStart() vec2.T {
+	for i := len(path.Segments) - 1; i >= 0; i-- {
+		if path.Segments[i].Command == Move {
+			return path.Segments[i].Points[0]
+		}
+	}
+	return vec2.Zero
+}
+
+// MoveTo starts a new sub-path at p.
+func (path *T) MoveTo(p *vec2.T) *T {
+	path.Segments = append(path.Segments, Segment{Command: Move, Points: [3]vec2.T{*p}})
+	return path
+}
+
+// LineTo adds a line from the current point to p.
+// An empty path starts at (0,0).
+func (path *T) LineTo(p *vec2.T) *T {
+	path.Segments = append(path.Segments, Segment{Command: Line, Points: [3]vec2.T{*p}})
+	return path
+}
+
+// QuadTo adds a quadratic bezier spline from the current point
+// with the control point c to p.
+func (path *T) QuadTo(c, p *vec2.T) *T {
+	path.Segments = append(path.Segments, Segment{Command: Quad, Points: [3]vec2.T{*c, *p}})
+	return path
+}
+
+// CubicTo adds a cubic bezier spline from the current point
+// with the control points c1 and c2 to p.
+func (path *T) CubicTo(c1, c2, p *vec2.T) *T {
+	path.Segments = append(path.Segments, Segment{Command: Cubic, Points: [3]vec2.T{*c1, *c2, *p}})
+	return path
+}
+
+// Close adds a line back to the start of the current sub-path
+// and closes it. The next segment starts at that point.
+func (path *T) Close() *T {
+	path.Segments = append(path.Segments, Segment{Command: ClosePath, Points: [3]vec2.T{path.subPathStart()}})
+	return path
+}
@@ -0,0 +1,62 @@
+package path2
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/vec2"
+)
+
+const EPSILON = 0.0001
+
+func TestBuilder(t *testing.T) {
+	var path T
+	path.MoveTo(&vec2.T{1, 1}).LineTo(&vec2.T{3, 1}).QuadTo(&vec2.T{4, 2}, &vec2.T{3, 3}).Close()
+	if len(path.Segments) != 4 {
+		t.Fatalf("expected 4 segments, got %v", path.Segments)
+	}
+	if got := path.CurrentPoint(); got != (vec2.T{1, 1}) {
+		t.Errorf("close should end at the start of the sub-path, got %v", got)
+	}
+	path.CubicTo(&vec2.T{0, 0}, &vec2.T{0, 2}, &vec2.T{-1, 1})
+	if got := path.CurrentPoint(); got != (vec2.T{-1, 1}) {
+		t.Errorf("current point after cubic failed: got %v", got)
+	}
+	path.MoveTo(&vec2.T{5, 5}).LineTo(&vec2.T{6, 5}).Close()
+	if got := path.CurrentPoint(); got != (vec2.T{5, 5}) {
+		t.Errorf("close should end at the start of the second sub-path, got %v", got)
+	}
+
+	var empty T
+	if got := empty.CurrentPoint(); got != vec2.Zero {
+		t.Errorf("current point of empty path should be zero, got %v", got)
+	}
+}
+
+func TestSegmentConversion(t *testing.T) {
+	start := vec2.T{0, 0}
+	segments := []Segment{
+		{Command: Line, Points: [3]vec2.T{{3, 6}}},
+		{Command: ClosePath, Points: [3]vec2.T{{3, 6}}},
+		{Command: Quad, Points: [3]vec2.T{{1, 2}, {3, 0}}},
+		{Command: Cubic, Points: [3]vec2.T{{1, 2}, {2, 2}, {3, 0}}},
+	}
+	for _, seg := range segments {
+		bez := seg.Bezier(&start)
+		if bez.P0 != start || bez.P3 != seg.End() {
+			t.Errorf("bezier of %v does not connect start and end: %v", seg, bez)
+		}
+		if seg.Command == Cubic {
+			continue
+		}
+		quad := seg.Quadratic(&start)
+		for _, s := range []float32{0, 0.25, 0.5, 0.75, 1} {
+			if got, want := bez.Point(s), quad.Point(s); !got.PracticallyEquals(&want, EPSILON) {
+				t.Errorf("bezier and quadratic of %v differ at %f: %v != %v", seg, s, got, want)
+			}
+		}
+	}
+	line := segments[0].Bezier(&start)
+	if got := line.Point(0.25); !got.PracticallyEquals(&vec2.T{0.75, 1.5}, EPSILON) {
+		t.Errorf("line should have uniform speed, got %v", got)
+	}
+}
@@ -0,0 +1,284 @@
+package path2
+
+import (
+	"fmt"
+	"strconv"
+
+	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/vec2"
+)
+
+// Parse parses T from SVG path data, the d attribute of a path element.
+// All commands are supported in absolute and relative form.
+// H and V are converted to Line, S and T to Cubic and Quad segments
+// and arcs to Cubic segments, see ArcTo(). See also String().
+func Parse(s string) (r T, err error) {
+	p := parser{s: s}
+	var (
+		cmd byte
+		// Reflected control point for S and T commands
+		lastControl vec2.T
+		lastCmd     byte
+	)
+	p.skipSpace()
+	for p.pos < len(p.s) {
+		if c := p.s[p.pos]; isCommand(c) {
+			cmd = c
+			p.pos++
+		} else if cmd == 0 {
+			return r, p.errorf("path data must start with a move command")
+		} else if cmd == 'Z' || cmd == 'z' {
+			return r, p.errorf("unexpected number after close command")
+		}
+		// Command letters can be omitted when a command repeats,
+		// a repeated move command is a line command.
+		if cmd != 'M' && cmd != 'm' && len(r.Segments) == 0 {
+			return r, p.errorf("path data must start with a move command")
+		}
+
+		current := r.CurrentPoint()
+		relative := cmd >= 'a'
+		point := func() (vec2.T, error) {
+			x, err := p.number()
+			if err != nil {
+				return vec2.T{}, err
+			}
+			y, err := p.number()
+			if err != nil {
+				return vec2.T{}, err
+			}
+			if relative {
+				return vec2.T{current[0] + x, current[1] + y}, nil
+			}
+			return vec2.T{x, y}, nil
+		}
+
+		var pts [3]vec2.T
+		switch cmd {
+		case 'M', 'm':
+			if pts[0], err = point(); err != nil {
+				return r, err
+			}
+			r.MoveTo(&pts[0])
+			// Following coordinates are implicit line commands
+			cmd -= 'M' - 'L'
+
+		case 'L', 'l':
+			if pts[0], err = point(); err != nil {
+				return r, err
+			}
+			r.LineTo(&pts[0])
+
+		case 'H', 'h', 'V', 'v':
+			v, err := p.number()
+			if err != nil {
+				return r, err
+			}
+			pts[0] = current
+			i := 0
+			if cmd == 'V' || cmd == 'v' {
+				i = 1
+			}
+			if relative {
+				pts[0][i] += v
+			} else {
+				pts[0][i] = v
+			}
+			r.LineTo(&pts[0])
+
+		case 'C', 'c', 'S', 's':
+			first := 0
+			if cmd == 'S' || cmd == 's' {
+				// First control point is the reflection of the previous one
+				pts[0] = current
+				if lastCmd == 'C' || lastCmd == 'S' {
+					pts[0] = vec2.T{2*current[0] - lastControl[0], 2*current[1] - lastControl[1]}
+				}
+				first = 1
+			}
+			for i := first; i < 3; i++ {
+				if pts[i], err = point(); err != nil {
+					return r, err
+				}
+			}
+			r.CubicTo(&pts[0], &pts[1], &pts[2])
+			lastControl = pts[1]
+
+		case 'Q', 'q', 'T', 't':
+			first := 0
+			if cmd == 'T' || cmd == 't' {
+				pts[0] = current
+				if lastCmd == 'Q' || lastCmd == 'T' {
+					pts[0] = vec2.T{2*current[0] - lastControl[0], 2*current[1] - lastControl[1]}
+				}
+				first = 1
+			}
+			for i := first; i < 2; i++ {
+				if pts[i], err = point(); err != nil {
+					return r, err
+				}
+			}
+			r.QuadTo(&pts[0], &pts[1])
+			lastControl = pts[0]
+
+		case 'A', 'a':
+			var radius vec2.T
+			if radius[0], err = p.number(); err != nil {
+				return r, err
+			}
+			if radius[1], err = p.number(); err != nil {
+				return r, err
+			}
+			rotation, err := p.number()
+			if err != nil {
+				return r, err
+			}
+			largeArc, err := p.flag()
+			if err != nil {
+				return r, err
+			}
+			sweep, err := p.flag()
+			if err != nil {
+				return r, err
+			}
+			if pts[0], err = point(); err != nil {
+				return r, err
+			}
+			r.ArcTo(&radius, rotation*math.Pi/180, largeArc, sweep, &pts[0])
+
+		case 'Z', 'z':
+			r.Close()
+		}
+		// Commands are stored in upper case for the reflection of control points
+		lastCmd = cmd &^ 0x20
+		p.skipSpace()
+	}
+	return r, nil
+}
+
+// String formats T as SVG path data with absolute commands. See also Parse().
+func (path *T) String() string {
+	var buf []byte
+	for i := range path.Segments {
+		seg := &path.Segments[i]
+		if i > 0 {
+			buf = append(buf, ' ')
+		}
+		buf = append(buf, byte(seg.Command))
+		var n int
+		switch seg.Command {
+		case Move, Line:
+			n = 1
+		case Quad:
+			n = 2
+		case Cubic:
+			n = 3
+		}
+		for j, p := range seg.Points[:n] {
+			if j > 0 {
+				buf = append(buf, ' ')
+			}
+			buf = appendFloat(buf, p[0])
+			buf = append(buf, ',')
+			buf = appendFloat(buf, p[1])
+		}
+	}
+	return string(buf)
+}
+
+func appendFloat(buf []byte, f float32) []byte {
+	return strconv.AppendFloat(buf, float64(f), 'g', -1, 32)
+}
+
+func isCommand(c byte) bool {
+	switch c &^ 0x20 {
+	case 'M', 'L', 'H', 'V', 'C', 'S', 'Q', 'T', 'A', 'Z':
+		return true
+	}
+	return false
+}
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid path data at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// skipSpace skips white space and at most one comma.
+func (p *parser) skipSpace() {
+	comma := false
+	for p.pos < len(p.s) {
+		switch c := p.s[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+		case c == ',' && !comma:
+			comma = true
+		default:
+			return
+		}
+		p.pos++
+	}
+}
+
+// number parses a number with optional sign, fraction and exponent.
+// Numbers don't need separators if they can be distinguished,
+// like in "1-2" or "0.5.5".
+func (p *parser) number() (float32, error) {
+	p.skipSpace()
+	start := p.pos
+	if p.pos < len(p.s) && (p.s[p.pos] == '+' || p.s[p.pos] == '-') {
+		p.pos++
+	}
+	digits := p.digits()
+	if p.pos < len(p.s) && p.s[p.pos] == '.' {
+		p.pos++
+		digits += p.digits()
+	}
+	if digits == 0 {
+		p.pos = start
+		return 0, p.errorf("expected number")
+	}
+	if p.pos < len(p.s) && (p.s[p.pos] == 'e' || p.s[p.pos] == 'E') {
+		exp := p.pos
+		p.pos++
+		if p.pos < len(p.s) && (p.s[p.pos] == '+' || p.s[p.pos] == '-') {
+			p.pos++
+		}
+		if p.digits() == 0 {
+			p.pos = exp
+		}
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.pos], 32)
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("%s", err)
+	}
+	return float32(f), nil
+}
+
+func (p *parser) digits() int {
+	start := p.pos
+	for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	return p.pos - start
+}
+
+// flag parses an arc flag, which is a single 0 or 1
+// that does not need a separator from the following number.
+func (p *parser) flag() (bool, error) {
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		switch p.s[p.pos] {
+		case '0':
+			p.pos++
+			return false, nil
+		case '1':
+			p.pos++
+			return true, nil
+		}
+	}
+	return false, p.errorf("expected arc flag 0 or 1")
+}
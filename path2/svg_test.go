@@ -0,0 +1,211 @@
+package path2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/vec2"
+)
+
+func mustParse(t *testing.T, s string) T {
+	t.Helper()
+	path, err := Parse(s)
+	if err != nil {
+		t.Fatalf("parsing %q failed: %s", s, err)
+	}
+	return path
+}
+
+func equalPaths(a, b *T) bool {
+	if len(a.Segments) != len(b.Segments) {
+		return false
+	}
+	for i := range a.Segments {
+		sa, sb := &a.Segments[i], &b.Segments[i]
+		if sa.Command != sb.Command {
+			return false
+		}
+		for j := range sa.Points {
+			if !sa.Points[j].PracticallyEquals(&sb.Points[j], EPSILON) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestParse(t *testing.T) {
+	path := mustParse(t, "M10 20 L30 40 H50 V60 Q 70,60 70,80 C70 90 60 100 50 100 Z")
+	want := T{Segments: []Segment{
+		{Command: Move, Points: [3]vec2.T{{10, 20}}},
+		{Command: Line, Points: [3]vec2.T{{30, 40}}},
+		{Command: Line, Points: [3]vec2.T{{50, 40}}},
+		{Command: Line, Points: [3]vec2.T{{50, 60}}},
+		{Command: Quad, Points: [3]vec2.T{{70, 60}, {70, 80}}},
+		{Command: Cubic, Points: [3]vec2.T{{70, 90}, {60, 100}, {50, 100}}},
+		{Command: ClosePath, Points: [3]vec2.T{{10, 20}}},
+	}}
+	if !equalPaths(&path, &want) {
+		t.Errorf("parsing absolute commands failed: got %v, want %v", path.Segments, want.Segments)
+	}
+
+	relative := mustParse(t, "m10 20 l20 20 h20 v20 q20 0 20 20 c0 10-10 20-20 20z")
+	if !equalPaths(&relative, &want) {
+		t.Errorf("parsing relative commands failed: got %v, want %v", relative.Segments, want.Segments)
+	}
+
+	// Implicit line commands after move, numbers without separators and exponents
+	path = mustParse(t, "M1-2.5.5.5L3e1,4 5E-1 6")
+	want = T{Segments: []Segment{
+		{Command: Move, Points: [3]vec2.T{{1, -2.5}}},
+		{Command: Line, Points: [3]vec2.T{{0.5, 0.5}}},
+		{Command: Line, Points: [3]vec2.T{{30, 4}}},
+		{Command: Line, Points: [3]vec2.T{{0.5, 6}}},
+	}}
+	if !equalPaths(&path, &want) {
+		t.Errorf("parsing compact numbers failed: got %v, want %v", path.Segments, want.Segments)
+	}
+
+	// Relative move after close starts at the start of the closed sub-path
+	path = mustParse(t, "M1 1 L2 1 Z m1 1 l1 0")
+	if got := path.CurrentPoint(); got != (vec2.T{3, 2}) {
+		t.Errorf("relative move after close failed: got %v", got)
+	}
+
+	if empty := mustParse(t, "  "); len(empty.Segments) != 0 {
+		t.Errorf("expected empty path, got %v", empty.Segments)
+	}
+}
+
+func TestParseReflection(t *testing.T) {
+	path := mustParse(t, "M0 0 C1 1 2 1 3 0 S5 -1 6 0 s2 1 3 0")
+	if got := path.Segments[2].Points[0]; got != (vec2.T{4, -1}) {
+		t.Errorf("reflected control point of S failed: got %v", got)
+	}
+	if got := path.Segments[3].Points; got != [3]vec2.T{{7, 1}, {8, 1}, {9, 0}} {
+		t.Errorf("relative S failed: got %v", got)
+	}
+	path = mustParse(t, "M0 0 L1 1 S2 2 3 1")
+	if got := path.Segments[2].Points[0]; got != (vec2.T{1, 1}) {
+		t.Errorf("S without previous cubic should use the current point, got %v", got)
+	}
+
+	path = mustParse(t, "M0 0 Q1 1 2 0 T4 0 t2 0")
+	if got := path.Segments[2].Points[0]; got != (vec2.T{3, -1}) {
+		t.Errorf("reflected control point of T failed: got %v", got)
+	}
+	if got := path.Segments[3].Points[0]; got != (vec2.T{5, 1}) {
+		t.Errorf("reflected control point of relative T failed: got %v", got)
+	}
+}
+
+// checkEllipse checks that the cubic segments after the move lie on the ellipse
+// with the given center, radii and rotation within maxError.
+func checkEllipse(t *testing.T, path *T, center, radius vec2.T, rotation float64, maxError float64) {
+	t.Helper()
+	sin, cos := math.Sincos(rotation)
+	start := path.Segments[0].End()
+	for i := range path.Segments[1:] {
+		seg := &path.Segments[i+1]
+		if seg.Command != Cubic {
+			t.Fatalf("expected cubic segments, got %v", seg)
+		}
+		bez := seg.Bezier(&start)
+		for s := float32(0); s <= 1; s += 1.0 / 16 {
+			p := bez.Point(s)
+			dx := float64(p[0] - center[0])
+			dy := float64(p[1] - center[1])
+			x := (cos*dx + sin*dy) / float64(radius[0])
+			y := (-sin*dx + cos*dy) / float64(radius[1])
+			if d := math.Abs(math.Hypot(x, y)-1) * float64(min(radius[0], radius[1])); d > maxError {
+				t.Errorf("point %v of arc segment %d deviates %f from the ellipse", p, i, d)
+			}
+		}
+		start = seg.End()
+	}
+}
+
+func TestParseArc(t *testing.T) {
+	// Half circle through (0,10)
+	path := mustParse(t, "M10 0 A10 10 0 0 1 -10 0")
+	if len(path.Segments) != 3 || path.CurrentPoint() != (vec2.T{-10, 0}) {
+		t.Fatalf("expected two segments to (-10,0), got %v", path.Segments)
+	}
+	checkEllipse(t, &path, vec2.T{0, 0}, vec2.T{10, 10}, 0, 0.01)
+	if got := path.Segments[1].End(); !got.PracticallyEquals(&vec2.T{0, 10}, EPSILON) {
+		t.Errorf("half circle should pass (0,10), got %v", got)
+	}
+
+	// Large arc in negative direction around (0,0) through (0,-10)
+	path = mustParse(t, "M10 0 A10 10 0 1 0 0 10")
+	if len(path.Segments) != 4 {
+		t.Fatalf("expected three segments, got %v", path.Segments)
+	}
+	checkEllipse(t, &path, vec2.T{0, 0}, vec2.T{10, 10}, 0, 0.01)
+	if got := path.Segments[1].End(); !got.PracticallyEquals(&vec2.T{0, -10}, EPSILON) {
+		t.Errorf("large arc should pass (0,-10), got %v", got)
+	}
+
+	// Small arc in negative direction around (10,10)
+	path = mustParse(t, "M10 0 A10 10 0 0 0 0 10")
+	if len(path.Segments) != 2 {
+		t.Fatalf("expected one segment, got %v", path.Segments)
+	}
+	checkEllipse(t, &path, vec2.T{10, 10}, vec2.T{10, 10}, 0, 0.01)
+
+	// Too small radii are scaled up
+	path = mustParse(t, "M0 0 a1 1 0 0 1 10 0")
+	checkEllipse(t, &path, vec2.T{5, 0}, vec2.T{5, 5}, 0, 0.01)
+
+	// Rotated ellipse with the major axis along y
+	path = mustParse(t, "M0 0 A20 10 90 0 1 0 40")
+	checkEllipse(t, &path, vec2.T{0, 20}, vec2.T{20, 10}, math.Pi/2, 0.01)
+	if got := path.Segments[1].End(); !got.PracticallyEquals(&vec2.T{10, 20}, 0.001) {
+		t.Errorf("rotated arc should pass (10,20), got %v", got)
+	}
+
+	// Zero radius is a line and an arc to the current point is omitted
+	path = mustParse(t, "M0 0 A0 10 0 0 1 5 5 A10 10 0 0 1 5 5")
+	if len(path.Segments) != 2 || path.Segments[1].Command != Line {
+		t.Errorf("expected a line, got %v", path.Segments)
+	}
+	// Flags without separators
+	path = mustParse(t, "M0 0a5 5 0 1110 0")
+	checkEllipse(t, &path, vec2.T{5, 0}, vec2.T{5, 5}, 0, 0.01)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{
+		"L1 2",
+		"10 20",
+		"M1",
+		"M1 2 Z 3 4",
+		"M1 2 A1 1 0 2 1 3 3",
+		"M1 x",
+		"M1 2 L3",
+		"M1 2 X3 4",
+	} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	s := "M0,0 L10,0 Q15,5 10,10 C5,15 0,15 0,10.5 Z"
+	path := mustParse(t, s)
+	if got := path.String(); got != s {
+		t.Errorf("formatting failed: got %q, want %q", got, s)
+	}
+
+	path = mustParse(t, "m1.25 2 h3 v-1e-3 s1 1 2 0 t1 1 a1 2 30 0 1 3 3 z")
+	parsed := mustParse(t, path.String())
+	if !equalPaths(&parsed, &path) {
+		t.Errorf("parsing formatted path failed: got %v, want %v", parsed.Segments, path.Segments)
+	}
+
+	var empty T
+	if got := empty.String(); got != "" {
+		t.Errorf("empty path should format as empty string, got %q", got)
+	}
+}
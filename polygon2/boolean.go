@@ -0,0 +1,358 @@
+package polygon2
+
+import (
+	"sort"
+
+	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/vec2"
+)
+
+// Operation is a boolean operation on the areas of two polygons.
+type Operation int
+
+const (
+	// Union is the area of both polygons.
+	Union Operation = iota
+	// Intersection is the area that is inside of both polygons.
+	Intersection
+	// Difference is the area of the first polygon that is not inside of the second one.
+	Difference
+	// Xor is the area that is inside of exactly one of the polygons.
+	Xor
+)
+
+// Boolean returns the result of the boolean operation on the polygons a and b,
+// which are made of outlines and holes.
+// The rings of a and b may have any winding,
+// rings that are inside of an odd number of other rings are holes.
+// The resulting outlines are left winding and the holes are right winding.
+// Edges of a and b that overlap are handled, the rings must not intersect
+// other rings of the same polygon.
+// All pairs of edges are intersected, so the time is quadratic in the number of edges.
+func Boolean(a, b []T, op Operation) [][]vec2.T {
+	shapes := [2][]T{orientedRings(a), orientedRings(b)}
+	segments := splitSegments(shapes)
+
+	// Edges of a that are shared with b
+	type edge struct{ a, b vec2.T }
+	fromB := make(map[edge]bool)
+	for _, s := range segments {
+		if s.shape == 1 {
+			fromB[edge{s.a, s.b}] = true
+		}
+	}
+	sharedWithA := make(map[edge]bool)
+
+	var selected []segment
+	for _, s := range segments {
+		if s.shape == 0 {
+			same, opposite := fromB[edge{s.a, s.b}], fromB[edge{s.b, s.a}]
+			if same || opposite {
+				if same {
+					sharedWithA[edge{s.a, s.b}] = true
+				} else {
+					sharedWithA[edge{s.b, s.a}] = true
+				}
+				// Both areas are on the same side of a shared edge with the same direction
+				// and on different sides of one with the opposite direction
+				if same && (op == Union || op == Intersection) || opposite && op == Difference {
+					selected = append(selected, s)
+				}
+				continue
+			}
+		} else if sharedWithA[edge{s.a, s.b}] {
+			continue
+		}
+
+		mid := vec2.Interpolate(&s.a, &s.b, 0.5)
+		inside := windingNumber(shapes[1-s.shape], &mid) != 0
+		reversed := segment{a: s.b, b: s.a, shape: s.shape}
+		switch op {
+		case Union:
+			if !inside {
+				selected = append(selected, s)
+			}
+		case Intersection:
+			if inside {
+				selected = append(selected, s)
+			}
+		case Difference:
+			if s.shape == 0 && !inside {
+				selected = append(selected, s)
+			} else if s.shape == 1 && inside {
+				selected = append(selected, reversed)
+			}
+		case Xor:
+			if inside {
+				selected = append(selected, reversed)
+			} else {
+				selected = append(selected, s)
+			}
+		}
+	}
+	return linkRings(selected)
+}
+
+// orientedRings returns copies of the rings without consecutive duplicate points,
+// where outlines are left winding and holes are right winding.
+func orientedRings(rings []T) []T {
+	result := make([]T, 0, len(rings))
+	for _, ring := range rings {
+		r := make(T, 0, len(ring))
+		for i := range ring {
+			if len(r) == 0 || ring[i] != r[len(r)-1] {
+				r = append(r, ring[i])
+			}
+		}
+		for len(r) > 1 && r[0] == r[len(r)-1] {
+			r = r[:len(r)-1]
+		}
+		if len(r) >= 3 && r.Area() != 0 {
+			result = append(result, r)
+		}
+	}
+	for i := range result {
+		depth := 0
+		for j := range result {
+			if j != i && result[j].WindingNumber(&result[i][0]) != 0 {
+				depth++
+			}
+		}
+		if depth%2 == 0 {
+			result[i].MakeLeftWinding()
+		} else {
+			result[i].MakeRightWinding()
+		}
+	}
+	return result
+}
+
+// windingNumber returns the sum of the winding numbers of the rings around p.
+func windingNumber(rings []T, p *vec2.T) int {
+	winding := 0
+	for i := range rings {
+		winding += rings[i].WindingNumber(p)
+	}
+	return winding
+}
+
+// segment is a directed edge of one of the two shapes of a boolean operation.
+type segment struct {
+	a, b  vec2.T
+	shape int
+}
+
+// machineEpsilon is the relative rounding error of float32.
+const machineEpsilon = 0x1p-23
+
+// snapTolerance is the distance in multiples of the rounding error of the coordinates
+// below which points are snapped together.
+const snapTolerance = 64
+
+// snapper replaces points by the first point that was snapped within the tolerance.
+type snapper struct {
+	points    []vec2.T
+	tolerance float32
+}
+
+func (s *snapper) snap(p vec2.T) vec2.T {
+	for i := range s.points {
+		if d := vec2.Sub(&p, &s.points[i]); d.LengthSqr() <= s.tolerance*s.tolerance {
+			return s.points[i]
+		}
+	}
+	s.points = append(s.points, p)
+	return p
+}
+
+// splitSegments returns the edges of the rings of both shapes split at all intersections,
+// so that edges only touch at their end points.
+// Points that nearly coincide because of rounding errors are snapped to one shared point,
+// and edges that touch within that tolerance are split at the touching point,
+// otherwise the edges at these points could not be linked to rings.
+func splitSegments(shapes [2][]T) []segment {
+	var extent float32
+	for _, rings := range shapes {
+		for _, ring := range rings {
+			for i := range ring {
+				extent = max(extent, math.Abs(ring[i][0]), math.Abs(ring[i][1]))
+			}
+		}
+	}
+	snaps := snapper{tolerance: snapTolerance * machineEpsilon * extent}
+	var edges []segment
+	for shape, rings := range shapes {
+		for _, ring := range rings {
+			for i := range ring {
+				a, b := snaps.snap(ring[i]), snaps.snap(ring[(i+1)%len(ring)])
+				if a != b {
+					edges = append(edges, segment{a: a, b: b, shape: shape})
+				}
+			}
+		}
+	}
+
+	type split struct {
+		t     float32
+		point vec2.T
+	}
+	splits := make([][]split, len(edges))
+	addSplit := func(e int, t float32, p vec2.T) {
+		if t > 0 && t < 1 && p != edges[e].a && p != edges[e].b {
+			splits[e] = append(splits[e], split{t, p})
+		}
+	}
+	bounds := make([]vec2.Rect, len(edges))
+	for i := range edges {
+		bounds[i] = vec2.Rect{Min: vec2.Min(&edges[i].a, &edges[i].b), Max: vec2.Max(&edges[i].a, &edges[i].b)}
+	}
+	for i := range edges {
+		for j := i + 1; j < len(edges); j++ {
+			bi, bj := &bounds[i], &bounds[j]
+			if bi.Max[0]+snaps.tolerance < bj.Min[0] || bj.Max[0]+snaps.tolerance < bi.Min[0] ||
+				bi.Max[1]+snaps.tolerance < bj.Min[1] || bj.Max[1]+snaps.tolerance < bi.Min[1] {
+				continue
+			}
+			p, p2, q, q2 := &edges[i].a, &edges[i].b, &edges[j].a, &edges[j].b
+			r, s, pq := vec2.Sub(p2, p), vec2.Sub(q2, q), vec2.Sub(q, p)
+			denom := vec2.Cross(&r, &s)
+			if denom == 0 {
+				if vec2.Cross(&pq, &r) != 0 {
+					// Parallel
+					continue
+				}
+				// Collinear edges overlap between the end points inside of the other edge
+				rr, ss := vec2.Dot(&r, &r), vec2.Dot(&s, &s)
+				for _, end := range [2]*vec2.T{q, q2} {
+					d := vec2.Sub(end, p)
+					addSplit(i, vec2.Dot(&d, &r)/rr, *end)
+				}
+				for _, end := range [2]*vec2.T{p, p2} {
+					d := vec2.Sub(end, q)
+					addSplit(j, vec2.Dot(&d, &s)/ss, *end)
+				}
+				continue
+			}
+			if *p == *q || *p == *q2 || *p2 == *q || *p2 == *q2 {
+				// Edges that are not parallel intersect only in their shared end point
+				continue
+			}
+			t := vec2.Cross(&pq, &s) / denom
+			u := vec2.Cross(&pq, &r) / denom
+			// Parameter tolerances of the snap tolerance along the edges
+			dt, du := snaps.tolerance/r.Length(), snaps.tolerance/s.Length()
+			if t < -dt || t > 1+dt || u < -du || u > 1+du {
+				continue
+			}
+			// End points are used exactly for touching edges
+			var point vec2.T
+			switch {
+			case t <= dt:
+				point = *p
+			case t >= 1-dt:
+				point = *p2
+			case u <= du:
+				point = *q
+			case u >= 1-du:
+				point = *q2
+			default:
+				point = vec2.Interpolate(p, p2, t)
+			}
+			addSplit(i, t, point)
+			addSplit(j, u, point)
+		}
+	}
+
+	for i := range splits {
+		for j := range splits[i] {
+			splits[i][j].point = snaps.snap(splits[i][j].point)
+		}
+	}
+
+	var result []segment
+	for i, e := range edges {
+		s := splits[i]
+		sort.Slice(s, func(a, b int) bool { return s[a].t < s[b].t })
+		start := e.a
+		for _, sp := range s {
+			if sp.point != start {
+				result = append(result, segment{a: start, b: sp.point, shape: e.shape})
+				start = sp.point
+			}
+		}
+		if start != e.b {
+			result = append(result, segment{a: start, b: e.b, shape: e.shape})
+		}
+	}
+	return result
+}
+
+// linkRings connects the directed edges to rings.
+// At points with several outgoing edges the one with the sharpest turn to the left is used,
+// so that rings that touch in a point are separated.
+// Points in the middle of straight lines are removed.
+func linkRings(edges []segment) [][]vec2.T {
+	outgoing := make(map[vec2.T][]int, len(edges))
+	for i := range edges {
+		outgoing[edges[i].a] = append(outgoing[edges[i].a], i)
+	}
+	used := make([]bool, len(edges))
+
+	var rings [][]vec2.T
+	for first := range edges {
+		if used[first] {
+			continue
+		}
+		used[first] = true
+		ring := T{edges[first].a}
+		e := first
+		for edges[e].b != edges[first].a {
+			back := vec2.Sub(&edges[e].a, &edges[e].b)
+			next, bestAngle := -1, float32(0)
+			for _, candidate := range outgoing[edges[e].b] {
+				if used[candidate] {
+					continue
+				}
+				// Clockwise angle from the incoming edge back to the candidate
+				d := vec2.Sub(&edges[candidate].b, &edges[candidate].a)
+				angle := math.Atan2(vec2.Cross(&d, &back), vec2.Dot(&d, &back))
+				if angle <= 0 {
+					angle += 2 * math.Pi
+				}
+				if next == -1 || angle < bestAngle {
+					next, bestAngle = candidate, angle
+				}
+			}
+			if next == -1 {
+				// Open chain because of rounding errors beyond the snap tolerance of splitSegments
+				ring = nil
+				break
+			}
+			used[next] = true
+			ring = append(ring, edges[next].a)
+			e = next
+		}
+		ring = withoutStraightPoints(ring)
+		if len(ring) >= 3 && ring.Area() != 0 {
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+// withoutStraightPoints returns the ring without points between collinear edges
+// that continue in the same direction.
+func withoutStraightPoints(ring T) T {
+	for changed := true; changed && len(ring) >= 3; {
+		changed = false
+		for i := 0; i < len(ring) && len(ring) >= 3; i++ {
+			a, b, c := &ring[(i+len(ring)-1)%len(ring)], &ring[i], &ring[(i+1)%len(ring)]
+			ab, bc := vec2.Sub(b, a), vec2.Sub(c, b)
+			if vec2.Cross(&ab, &bc) == 0 && vec2.Dot(&ab, &bc) > 0 {
+				ring = append(ring[:i], ring[i+1:]...)
+				changed = true
+			}
+		}
+	}
+	return ring
+}
@@ -0,0 +1,78 @@
+package polygon2
+
+import (
+	"github.com/ungerik/go3d/vec2"
+)
+
+// ClippedToRect returns the part of the polygon inside of rect
+// using the Sutherland-Hodgman algorithm.
+// The winding of the polygon is kept.
+// Concave polygons that are split into several parts by the clipping
+// result in one polygon with connecting edges along the border of rect.
+func (poly *T) ClippedToRect(rect *vec2.Rect) T {
+	result := append(T(nil), *poly...)
+	var buffer T
+	for axis := 0; axis < 2; axis++ {
+		low, high := rect.Min[axis], rect.Max[axis]
+		buffer = clipHalfPlane(result, buffer[:0], func(p *vec2.T) float32 { return p[axis] - low })
+		result, buffer = buffer, result
+		buffer = clipHalfPlane(result, buffer[:0], func(p *vec2.T) float32 { return high - p[axis] })
+		result, buffer = buffer, result
+	}
+	// Intersections on the border of rect are exactly on it
+	for i := range result {
+		result[i].Clamp(&rect.Min, &rect.Max)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// ClippedToConvex returns the part of the polygon inside of the convex polygon clip
+// of any winding using the Sutherland-Hodgman algorithm.
+// The winding of the polygon is kept.
+// Concave polygons that are split into several parts by the clipping
+// result in one polygon with connecting edges along the border of clip.
+func (poly *T) ClippedToConvex(clip *T) T {
+	c := *clip
+	if len(c) < 3 {
+		return nil
+	}
+	sign := float32(1)
+	if clip.IsRightWinding() {
+		sign = -1
+	}
+	result := append(T(nil), *poly...)
+	var buffer T
+	for i := range c {
+		a, b := &c[i], &c[(i+1)%len(c)]
+		edge := vec2.Sub(b, a)
+		// Inside is left of the edges of a left winding clip polygon
+		buffer = clipHalfPlane(result, buffer[:0], func(p *vec2.T) float32 {
+			ap := vec2.Sub(p, a)
+			return sign * vec2.Cross(&edge, &ap)
+		})
+		result, buffer = buffer, result
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// clipHalfPlane appends the points of polygon that are clipped to the half plane
+// of points with a distance that is not negative to dst.
+func clipHalfPlane(polygon, dst T, distance func(p *vec2.T) float32) T {
+	for i := range polygon {
+		a, b := &polygon[i], &polygon[(i+1)%len(polygon)]
+		da, db := distance(a), distance(b)
+		if da >= 0 {
+			dst = append(dst, *a)
+		}
+		if da < 0 && db > 0 || da > 0 && db < 0 {
+			dst = append(dst, vec2.Interpolate(a, b, da/(da-db)))
+		}
+	}
+	return dst
+}
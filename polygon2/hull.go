@@ -0,0 +1,57 @@
+package polygon2
+
+import (
+	"sort"
+
+	"github.com/ungerik/go3d/vec2"
+)
+
+// ConvexHull returns the smallest left winding convex polygon that contains all points
+// using Andrew's monotone chain algorithm.
+// Duplicate points and points on the edges of the hull are not part of the result.
+// If all points are collinear the result has only the two end points,
+// if all points are equal it has only one point.
+func ConvexHull(points []vec2.T) T {
+	if len(points) == 0 {
+		return nil
+	}
+	// Early out for points without area in x or y direction,
+	// the hull is the line between the extreme points
+	poly := T(points)
+	bounds := poly.BoundingBox()
+	if bounds.Min[0] == bounds.Max[0] || bounds.Min[1] == bounds.Max[1] {
+		if bounds.Min == bounds.Max {
+			return T{bounds.Min}
+		}
+		return T{bounds.Min, bounds.Max}
+	}
+
+	sorted := append([]vec2.T(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i][0] < sorted[j][0] || sorted[i][0] == sorted[j][0] && sorted[i][1] < sorted[j][1]
+	})
+
+	// The lower hull from left to right and the upper hull from right to left
+	// only turn left, points that don't are removed from the chain
+	hull := make(T, 0, len(sorted)+1)
+	appendChain := func(p *vec2.T, minLen int) {
+		for len(hull) >= minLen+2 {
+			a, b := &hull[len(hull)-2], &hull[len(hull)-1]
+			ab, bp := vec2.Sub(b, a), vec2.Sub(p, b)
+			if vec2.Cross(&ab, &bp) > 0 {
+				break
+			}
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, *p)
+	}
+	for i := range sorted {
+		appendChain(&sorted[i], 0)
+	}
+	lower := len(hull) - 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		appendChain(&sorted[i], lower)
+	}
+	// The last point is the first one
+	return hull[:len(hull)-1]
+}
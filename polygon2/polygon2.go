@@ -0,0 +1,241 @@
+// Package polygon2 contains a float32 type T for 2D polygons
+// and functions for their area, centroid, winding, convexity,
+// point containment, simplification, triangulation, convex hulls,
+// clipping and boolean operations.
+// Polygons are closed implicitly, the last point connects to the first one.
+// Left winding (counter clockwise in a coordinate system with the y axis up)
+// polygons have a positive area, like vec2.IsLeftWinding.
+package polygon2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/vec2"
+)
+
+// T is a polygon defined by its corner points.
+type T []vec2.T
+
+// Parse parses T from a string of space separated coordinates. See also String()
+func Parse(s string) (r T, err error) {
+	fields := strings.Fields(s)
+	if len(fields)%2 != 0 {
+		return nil, fmt.Errorf("odd number of polygon coordinates: %d", len(fields))
+	}
+	r = make(T, len(fields)/2)
+	for i, field := range fields {
+		f, err := strconv.ParseFloat(field, 32)
+		if err != nil {
+			return nil, err
+		}
+		r[i/2][i%2] = float32(f)
+	}
+	return r, nil
+}
+
+// String formats T as string. See also Parse().
+func (poly *T) String() string {
+	s := make([]string, len(*poly))
+	for i := range *poly {
+		s[i] = (*poly)[i].String()
+	}
+	return strings.Join(s, " ")
+}
+
+// Area returns the signed area of the polygon,
+// which is positive for left winding and negative for right winding polygons.
+// The area of self intersecting polygons is the sum of the signed areas of their parts.
+func (poly *T) Area() float32 {
+	p := *poly
+	if len(p) < 3 {
+		return 0
+	}
+	// Relative to the first point for better precision far from the origin
+	var area float32
+	for i := 1; i < len(p)-1; i++ {
+		a := vec2.Sub(&p[i], &p[0])
+		b := vec2.Sub(&p[i+1], &p[0])
+		area += vec2.Cross(&a, &b)
+	}
+	return area / 2
+}
+
+// Centroid returns the center of mass of the polygon area.
+// Returns the average of the points for polygons without area.
+func (poly *T) Centroid() vec2.T {
+	p := *poly
+	if len(p) == 0 {
+		return vec2.Zero
+	}
+	var area float32
+	var c vec2.T
+	for i := 1; i < len(p)-1; i++ {
+		a := vec2.Sub(&p[i], &p[0])
+		b := vec2.Sub(&p[i+1], &p[0])
+		cross := vec2.Cross(&a, &b)
+		area += cross
+		c[0] += (a[0] + b[0]) * cross
+		c[1] += (a[1] + b[1]) * cross
+	}
+	if area == 0 {
+		for i := range p {
+			c.Add(&p[i])
+		}
+		return *c.Scale(1 / float32(len(p)))
+	}
+	c.Scale(1 / (3 * area))
+	return *c.Add(&p[0])
+}
+
+// IsLeftWinding returns if the polygon has a positive area,
+// which means it is counter clockwise in a coordinate system with the y axis up.
+func (poly *T) IsLeftWinding() bool {
+	return poly.Area() > 0
+}
+
+// IsRightWinding returns if the polygon has a negative area,
+// which means it is clockwise in a coordinate system with the y axis up.
+func (poly *T) IsRightWinding() bool {
+	return poly.Area() < 0
+}
+
+// Reverse reverses the order of the points, which changes the winding.
+func (poly *T) Reverse() *T {
+	p := *poly
+	for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+		p[i], p[j] = p[j], p[i]
+	}
+	return poly
+}
+
+// Reversed returns a copy of the polygon with the points in reverse order.
+func (poly *T) Reversed() T {
+	r := make(T, len(*poly))
+	copy(r, *poly)
+	return *r.Reverse()
+}
+
+// MakeLeftWinding reverses the polygon if it is right winding.
+func (poly *T) MakeLeftWinding() *T {
+	if poly.IsRightWinding() {
+		poly.Reverse()
+	}
+	return poly
+}
+
+// MakeRightWinding reverses the polygon if it is left winding.
+func (poly *T) MakeRightWinding() *T {
+	if poly.IsLeftWinding() {
+		poly.Reverse()
+	}
+	return poly
+}
+
+// IsConvex returns if the polygon is convex and not self intersecting.
+// Collinear and duplicate points are allowed, polygons without area are not convex.
+func (poly *T) IsConvex() bool {
+	p := *poly
+	n := len(p)
+	if n < 3 {
+		return false
+	}
+	var sign, turn float32
+	prev := vec2.Sub(&p[0], &p[n-1])
+	for i := range p {
+		edge := vec2.Sub(&p[(i+1)%n], &p[i])
+		if edge.IsZero() {
+			continue
+		}
+		if !prev.IsZero() {
+			cross := vec2.Cross(&prev, &edge)
+			if cross*sign < 0 {
+				return false
+			}
+			if cross != 0 {
+				sign = cross
+			}
+			turn += math.Atan2(cross, vec2.Dot(&prev, &edge))
+		}
+		prev = edge
+	}
+	// A convex polygon turns exactly once around, a star shaped polygon more often
+	return sign != 0 && math.Abs(math.Abs(turn)-2*math.Pi) < 0.01
+}
+
+// Perimeter returns the length of the closed outline of the polygon.
+func (poly *T) Perimeter() float32 {
+	p := *poly
+	var length float32
+	for i := range p {
+		d := vec2.Sub(&p[(i+1)%len(p)], &p[i])
+		length += d.Length()
+	}
+	return length
+}
+
+// BoundingBox returns the axis aligned bounding box of the polygon.
+// Returns a zero rectangle for a polygon without points.
+func (poly *T) BoundingBox() vec2.Rect {
+	p := *poly
+	if len(p) == 0 {
+		return vec2.Rect{}
+	}
+	rect := vec2.Rect{Min: p[0], Max: p[0]}
+	for i := range p[1:] {
+		rect.Min = vec2.Min(&rect.Min, &p[i+1])
+		rect.Max = vec2.Max(&rect.Max, &p[i+1])
+	}
+	return rect
+}
+
+// WindingNumber returns how often the polygon winds around the point p,
+// positive for left and negative for right winding.
+// The result for points on the outline is undefined.
+func (poly *T) WindingNumber(p *vec2.T) int {
+	points := *poly
+	winding := 0
+	for i := range points {
+		a := &points[i]
+		b := &points[(i+1)%len(points)]
+		// Sign of the side of p relative to the edge from a to b
+		side := (b[0]-a[0])*(p[1]-a[1]) - (p[0]-a[0])*(b[1]-a[1])
+		if a[1] <= p[1] {
+			if b[1] > p[1] && side > 0 {
+				winding++
+			}
+		} else if b[1] <= p[1] && side < 0 {
+			winding--
+		}
+	}
+	return winding
+}
+
+// ContainsPoint returns if the point p is inside of the polygon
+// using the nonzero winding rule.
+// The result for points on the outline is undefined.
+func (poly *T) ContainsPoint(p *vec2.T) bool {
+	return poly.WindingNumber(p) != 0
+}
+
+// ContainsPointEvenOdd returns if the point p is inside of the polygon
+// using the even-odd rule, where self overlapping areas are outside
+// if they are covered an even number of times.
+// The result for points on the outline is undefined.
+func (poly *T) ContainsPointEvenOdd(p *vec2.T) bool {
+	points := *poly
+	inside := false
+	for i := range points {
+		a := &points[i]
+		b := &points[(i+1)%len(points)]
+		if (a[1] > p[1]) != (b[1] > p[1]) {
+			x := a[0] + (p[1]-a[1])*(b[0]-a[0])/(b[1]-a[1])
+			if p[0] < x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
@@ -0,0 +1,198 @@
+package polygon2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/vec2"
+)
+
+const EPSILON = 0.0001
+
+// square is a left winding square from (1,1) to (3,3)
+var square = T{{1, 1}, {3, 1}, {3, 3}, {1, 3}}
+
+// lShape is a left winding concave polygon
+var lShape = T{{0, 0}, {4, 0}, {4, 1}, {1, 1}, {1, 3}, {0, 3}}
+
+func abs(x float32) float32 {
+	return float32(math.Abs(float64(x)))
+}
+
+func TestParseAndString(t *testing.T) {
+	s := lShape.String()
+	parsed, err := Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != len(lShape) {
+		t.Fatalf("parsing %q failed: got %v", s, parsed)
+	}
+	for i := range parsed {
+		if parsed[i] != lShape[i] {
+			t.Errorf("point %d failed: got %v, want %v", i, parsed[i], lShape[i])
+		}
+	}
+	if _, err := Parse("1 2 3"); err == nil {
+		t.Errorf("expected error for odd number of coordinates")
+	}
+	if _, err := Parse("1 2 x 4"); err == nil {
+		t.Errorf("expected error for invalid number")
+	}
+}
+
+func TestAreaAndWinding(t *testing.T) {
+	if area := square.Area(); abs(area-4) > EPSILON || !square.IsLeftWinding() || square.IsRightWinding() {
+		t.Errorf("area of left winding square failed: %f", area)
+	}
+	if area := lShape.Area(); abs(area-6) > EPSILON {
+		t.Errorf("area of L shape failed: got %f, want 6", area)
+	}
+	reversed := lShape.Reversed()
+	if area := reversed.Area(); abs(area+6) > EPSILON || !reversed.IsRightWinding() {
+		t.Errorf("area of right winding L shape failed: %f", area)
+	}
+	if lShape[1] != (vec2.T{4, 0}) {
+		t.Errorf("Reversed must not modify the polygon")
+	}
+	reversed.MakeLeftWinding()
+	if !reversed.IsLeftWinding() || reversed[0] != lShape[0] {
+		t.Errorf("MakeLeftWinding failed: %v", reversed)
+	}
+	reversed.MakeLeftWinding()
+	if !reversed.IsLeftWinding() {
+		t.Errorf("MakeLeftWinding should not change a left winding polygon")
+	}
+	reversed.MakeRightWinding()
+	if !reversed.IsRightWinding() {
+		t.Errorf("MakeRightWinding failed: %v", reversed)
+	}
+
+	// Precision far away from the origin
+	far := T{{1e4, 1e4}, {1e4 + 1, 1e4}, {1e4 + 1, 1e4 + 1}, {1e4, 1e4 + 1}}
+	if area := far.Area(); abs(area-1) > EPSILON {
+		t.Errorf("area far from origin failed: got %f, want 1", area)
+	}
+	line := T{{0, 0}, {1, 1}}
+	if area := line.Area(); area != 0 {
+		t.Errorf("area of line should be zero, got %f", area)
+	}
+}
+
+func TestCentroid(t *testing.T) {
+	if c := square.Centroid(); !c.PracticallyEquals(&vec2.T{2, 2}, EPSILON) {
+		t.Errorf("centroid of square failed: got %v", c)
+	}
+	// L shape is a 4x1 and a 1x2 rectangle
+	want := vec2.T{(4*2 + 2*0.5) / 6, (4*0.5 + 2*2) / 6}
+	if c := lShape.Centroid(); !c.PracticallyEquals(&want, EPSILON) {
+		t.Errorf("centroid of L shape failed: got %v, want %v", c, want)
+	}
+	reversed := lShape.Reversed()
+	if c := reversed.Centroid(); !c.PracticallyEquals(&want, EPSILON) {
+		t.Errorf("centroid of right winding L shape failed: got %v, want %v", c, want)
+	}
+	line := T{{0, 0}, {2, 2}, {4, 4}}
+	if c := line.Centroid(); !c.PracticallyEquals(&vec2.T{2, 2}, EPSILON) {
+		t.Errorf("centroid of polygon without area failed: got %v", c)
+	}
+}
+
+func TestIsConvex(t *testing.T) {
+	for _, test := range []struct {
+		poly   T
+		convex bool
+	}{
+		{square, true},
+		{square.Reversed(), true},
+		{lShape, false},
+		{T{{0, 0}, {1, 0}, {2, 0}, {2, 2}, {2, 2}, {0, 2}}, true},
+		{T{{0, 0}, {1, 0}, {2, 0}}, false},
+		// Pentagram
+		{T{{0, 1}, {0.59, -0.81}, {-0.95, 0.31}, {0.95, 0.31}, {-0.59, -0.81}}, false},
+		{T{{0, 0}, {1, 0}}, false},
+	} {
+		if got := test.poly.IsConvex(); got != test.convex {
+			t.Errorf("IsConvex of %v failed: got %t", test.poly, got)
+		}
+	}
+}
+
+func TestPerimeterAndBoundingBox(t *testing.T) {
+	if got := lShape.Perimeter(); abs(got-14) > EPSILON {
+		t.Errorf("perimeter failed: got %f, want 14", got)
+	}
+	rect := lShape.BoundingBox()
+	if rect.Min != (vec2.T{0, 0}) || rect.Max != (vec2.T{4, 3}) {
+		t.Errorf("bounding box failed: got %v", rect)
+	}
+	var empty T
+	if rect := empty.BoundingBox(); rect != (vec2.Rect{}) {
+		t.Errorf("bounding box of empty polygon failed: got %v", rect)
+	}
+}
+
+func TestContainsPoint(t *testing.T) {
+	for _, test := range []struct {
+		p      vec2.T
+		inside bool
+	}{
+		{vec2.T{0.5, 0.5}, true},
+		{vec2.T{3.5, 0.5}, true},
+		{vec2.T{0.5, 2.5}, true},
+		{vec2.T{2, 2}, false},
+		{vec2.T{-1, 0.5}, false},
+		{vec2.T{5, 0.5}, false},
+	} {
+		if got := lShape.ContainsPoint(&test.p); got != test.inside {
+			t.Errorf("ContainsPoint %v failed: got %t", test.p, got)
+		}
+		if got := lShape.ContainsPointEvenOdd(&test.p); got != test.inside {
+			t.Errorf("ContainsPointEvenOdd %v failed: got %t", test.p, got)
+		}
+	}
+
+	p := vec2.T{2, 2}
+	if w := square.WindingNumber(&p); w != 1 {
+		t.Errorf("winding number of left winding square failed: got %d", w)
+	}
+	reversed := square.Reversed()
+	if w := reversed.WindingNumber(&p); w != -1 {
+		t.Errorf("winding number of right winding square failed: got %d", w)
+	}
+
+	// A square going around twice has winding number 2 and is outside with even-odd
+	twice := append(append(T{}, square...), square...)
+	if w := twice.WindingNumber(&p); w != 2 || !twice.ContainsPoint(&p) || twice.ContainsPointEvenOdd(&p) {
+		t.Errorf("double square failed: winding number %d", w)
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	// Square with noisy points along the edges
+	noisy := T{{0, 0}, {1, 0.01}, {2, 0}, {2.01, 1}, {2, 2}, {1, 2}, {0, 2}, {0, 1}}
+	simplified := noisy.Simplified(0.1)
+	if len(simplified) != 4 {
+		t.Fatalf("expected 4 points, got %v", simplified)
+	}
+	if area := simplified.Area(); abs(area-4) > EPSILON {
+		t.Errorf("area of simplified polygon failed: got %f", area)
+	}
+	// Only (1,2) and (0,1) are exactly on the lines between their neighbors
+	if exact := noisy.Simplified(0); len(exact) != len(noisy)-2 {
+		t.Errorf("simplification with zero epsilon should only remove collinear points, got %v", exact)
+	}
+
+	thin := T{{0, 0}, {5, 0.1}, {10, 0}, {5, -0.1}}
+	if simplified := thin.Simplified(1); len(simplified) != 3 || simplified.Area() == 0 {
+		t.Errorf("simplified polygon should keep an area, got %v", simplified)
+	}
+
+	line := []vec2.T{{0, 0}, {1, 0.05}, {2, -0.05}, {3, 0}, {4, 2}, {5, 4}}
+	if got := SimplifyPolyline(line, 0.1, nil); len(got) != 3 || got[1] != (vec2.T{3, 0}) {
+		t.Errorf("polyline simplification failed: got %v", got)
+	}
+	if got := SimplifyPolyline(line[:1], 0.1, nil); len(got) != 1 {
+		t.Errorf("polyline with one point failed: got %v", got)
+	}
+}
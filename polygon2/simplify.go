@@ -0,0 +1,99 @@
+package polygon2
+
+import (
+	"github.com/ungerik/go3d/vec2"
+)
+
+// Simplified returns the polygon with fewer points using the Ramer-Douglas-Peucker algorithm.
+// The outline of the simplified polygon deviates at most epsilon from the original one.
+// At least three points are kept if the polygon has an area.
+func (poly *T) Simplified(epsilon float32) T {
+	p := *poly
+	if len(p) < 4 {
+		return append(T(nil), p...)
+	}
+	// Split the closed outline at the point farthest from the first one
+	split := 0
+	var maxDist float32
+	for i := range p {
+		d := vec2.Sub(&p[i], &p[0])
+		if d.LengthSqr() > maxDist {
+			maxDist = d.LengthSqr()
+			split = i
+		}
+	}
+	if split == 0 {
+		return T{p[0]}
+	}
+	closed := append(append(make([]vec2.T, 0, len(p)+1), p...), p[0])
+	result := SimplifyPolyline(closed[:split+1], epsilon, nil)
+	result = SimplifyPolyline(closed[split:], epsilon, result[:len(result)-1])
+	result = result[:len(result)-1]
+
+	if len(result) < 3 {
+		// Keep the point farthest from the remaining line to not lose the area
+		var far int
+		maxDist = 0
+		for i := range p {
+			if d := segmentDistanceSqr(&p[i], &result[0], &result[len(result)-1]); d > maxDist {
+				maxDist = d
+				far = i
+			}
+		}
+		if maxDist > 0 {
+			if far < split {
+				result = T{p[0], p[far], p[split]}
+			} else {
+				result = T{p[0], p[split], p[far]}
+			}
+		}
+	}
+	return result
+}
+
+// SimplifyPolyline appends the points of the open polyline to dst that remain
+// after simplification with the Ramer-Douglas-Peucker algorithm.
+// The simplified polyline deviates at most epsilon from the original one
+// and keeps its first and last point.
+func SimplifyPolyline(points []vec2.T, epsilon float32, dst []vec2.T) []vec2.T {
+	if len(points) == 0 {
+		return dst
+	}
+	dst = append(dst, points[0])
+	if len(points) == 1 {
+		return dst
+	}
+	dst = simplify(points, epsilon*epsilon, dst)
+	return append(dst, points[len(points)-1])
+}
+
+// simplify appends the kept points between the first and last point.
+func simplify(points []vec2.T, epsilonSqr float32, dst []vec2.T) []vec2.T {
+	last := len(points) - 1
+	index := 0
+	var maxDist float32
+	for i := 1; i < last; i++ {
+		if d := segmentDistanceSqr(&points[i], &points[0], &points[last]); d > maxDist {
+			maxDist = d
+			index = i
+		}
+	}
+	if maxDist <= epsilonSqr {
+		return dst
+	}
+	dst = simplify(points[:index+1], epsilonSqr, dst)
+	dst = append(dst, points[index])
+	return simplify(points[index:], epsilonSqr, dst)
+}
+
+// segmentDistanceSqr returns the squared distance of p from the line segment from a to b.
+func segmentDistanceSqr(p, a, b *vec2.T) float32 {
+	ab := vec2.Sub(b, a)
+	ap := vec2.Sub(p, a)
+	if lengthSqr := ab.LengthSqr(); lengthSqr > 0 {
+		t := max(0, min(1, vec2.Dot(&ap, &ab)/lengthSqr))
+		ab.Scale(t)
+		ap.Sub(&ab)
+	}
+	return ap.LengthSqr()
+}
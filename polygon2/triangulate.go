@@ -0,0 +1,419 @@
+package polygon2
+
+import (
+	"sort"
+
+	"github.com/ungerik/go3d/float64/predicates"
+	vec2d "github.com/ungerik/go3d/float64/vec2"
+	"github.com/ungerik/go3d/vec2"
+)
+
+// Triangulate returns the triangles of the polygon outline with holes
+// using ear clipping, where the holes are connected to the outline by bridge edges.
+// The triangles are index triples into the points of the outline followed
+// by the points of all holes in their order, so index len(outline) is the first point of holes[0].
+// All triangles are left winding, the windings of outline and holes don't matter.
+// Holes must be inside of the outline and must not overlap.
+// Collinear and duplicate points don't result in triangles without area,
+// self intersecting input results in overlapping triangles.
+func Triangulate(outline T, holes []T) [][3]int {
+	e := newEarClipper(outline, holes)
+	if e == nil {
+		return nil
+	}
+	return e.clip()
+}
+
+// TriangulateDelaunay returns a constrained Delaunay triangulation of the polygon outline with holes,
+// which has the same indices, windings and requirements as Triangulate().
+// The edges of outline and holes are kept, all other edges are flipped
+// until no point is inside the circumcircle of a neighboring triangle.
+// This maximizes the minimum angle of the triangles, which is better for meshes,
+// but takes more time than Triangulate().
+func TriangulateDelaunay(outline T, holes []T) [][3]int {
+	e := newEarClipper(outline, holes)
+	if e == nil {
+		return nil
+	}
+	triangles := e.clip()
+
+	// Edges of the outline and holes are constrained
+	constrained := make(map[[2]int]bool)
+	addRing := func(offset, n int) {
+		for i := 0; i < n; i++ {
+			a, b := offset+i, offset+(i+1)%n
+			constrained[[2]int{a, b}] = true
+			constrained[[2]int{b, a}] = true
+		}
+	}
+	addRing(0, len(outline))
+	offset := len(outline)
+	for _, hole := range holes {
+		addRing(offset, len(hole))
+		offset += len(hole)
+	}
+	delaunayFlip(e.points, triangles, constrained)
+	return triangles
+}
+
+// earNode is a point of the polygon ring that is clipped,
+// it references the point by its index and the neighbors in the ring.
+type earNode struct {
+	index      int
+	prev, next int
+}
+
+type earClipper struct {
+	points []vec2.T
+	nodes  []earNode
+	start  int
+	count  int
+}
+
+func newEarClipper(outline T, holes []T) *earClipper {
+	if len(outline) < 3 {
+		return nil
+	}
+	e := &earClipper{points: make([]vec2.T, 0, len(outline))}
+	e.points = append(e.points, outline...)
+	for _, hole := range holes {
+		e.points = append(e.points, hole...)
+	}
+
+	e.start = e.addRing(0, len(outline), true)
+	// Holes are merged from right to left, so that bridges don't cross other holes
+	type holeRef struct {
+		node  int
+		right float32
+	}
+	var refs []holeRef
+	offset := len(outline)
+	for _, hole := range holes {
+		if len(hole) >= 3 {
+			node := e.addRing(offset, len(hole), false)
+			refs = append(refs, holeRef{node: node, right: e.points[e.nodes[node].index][0]})
+		}
+		offset += len(hole)
+	}
+	sort.SliceStable(refs, func(i, j int) bool { return refs[i].right > refs[j].right })
+	for _, ref := range refs {
+		e.bridgeHole(ref.node)
+	}
+	return e
+}
+
+// addRing adds the points from offset to offset+n as linked ring of nodes
+// with left winding for the outline or right winding for holes.
+// Returns the node of the point with the largest x.
+func (e *earClipper) addRing(offset, n int, leftWinding bool) int {
+	ring := T(e.points[offset : offset+n])
+	reverse := ring.IsLeftWinding() != leftWinding
+	first := len(e.nodes)
+	rightmost := first
+	for i := 0; i < n; i++ {
+		index := offset + i
+		if reverse {
+			index = offset + n - 1 - i
+		}
+		node := first + i
+		e.nodes = append(e.nodes, earNode{
+			index: index,
+			prev:  first + (i+n-1)%n,
+			next:  first + (i+1)%n,
+		})
+		if p, r := e.points[index], e.points[e.nodes[rightmost].index]; p[0] > r[0] || (p[0] == r[0] && p[1] < r[1]) {
+			rightmost = node
+		}
+	}
+	e.count += n
+	return rightmost
+}
+
+func (e *earClipper) point(node int) *vec2.T {
+	return &e.points[e.nodes[node].index]
+}
+
+// bridgeHole connects the hole ring with its rightmost node m to the outline ring
+// by two bridge edges to a visible node of the outline, see:
+// David Eberly, Triangulation by Ear Clipping, https://www.geometrictools.com/Documentation/TriangulationByEarClipping.pdf
+func (e *earClipper) bridgeHole(m int) {
+	mp := *e.point(m)
+	// Find the nearest edge that the ray from m in +x direction hits
+	best := -1
+	var bestX float32
+	node := e.start
+	for {
+		next := e.nodes[node].next
+		a, b := e.point(node), e.point(next)
+		if (a[1] <= mp[1] && b[1] >= mp[1] || b[1] <= mp[1] && a[1] >= mp[1]) && a[1] != b[1] {
+			x := a[0] + (mp[1]-a[1])*(b[0]-a[0])/(b[1]-a[1])
+			if x >= mp[0] && (best < 0 || x < bestX) {
+				bestX = x
+				// Endpoint with the larger x is the candidate for the bridge
+				best = node
+				if b[0] > a[0] {
+					best = next
+				}
+			}
+		}
+		node = next
+		if node == e.start {
+			break
+		}
+	}
+	if best < 0 {
+		// Hole is not inside of the outline
+		return
+	}
+	hit := vec2.T{bestX, mp[1]}
+	candidate := *e.point(best)
+
+	// Reflex nodes inside of the triangle m, hit, candidate could block the view,
+	// the one with the smallest angle to the ray is visible
+	if candidate != hit {
+		// pointInTriangle needs a left winding triangle,
+		// which is clockwise if the candidate is below the ray
+		t1, t2 := hit, candidate
+		if orient(&mp, &t1, &t2) < 0 {
+			t1, t2 = t2, t1
+		}
+		bestCos := float32(-2)
+		node = e.start
+		for {
+			p := e.point(node)
+			if *p == candidate || e.isReflex(node) && pointInTriangle(p, &mp, &t1, &t2) {
+				d := vec2.Sub(p, &mp)
+				cos := d[0] / d.Length()
+				if d.IsZero() {
+					cos = 2
+				}
+				if cos > bestCos || cos == bestCos && e.inSector(node, &mp) {
+					bestCos = cos
+					best = node
+				}
+			}
+			node = e.nodes[node].next
+			if node == e.start {
+				break
+			}
+		}
+	}
+	e.splice(best, m)
+}
+
+// splice inserts the ring of m after the outline node p
+// with duplicated nodes for both ends of the bridge: p, m, ..., m', p'.
+func (e *earClipper) splice(p, m int) {
+	p2 := len(e.nodes)
+	m2 := p2 + 1
+	e.nodes = append(e.nodes,
+		earNode{index: e.nodes[p].index},
+		earNode{index: e.nodes[m].index},
+	)
+	pNext := e.nodes[p].next
+	mPrev := e.nodes[m].prev
+
+	e.nodes[p].next = m
+	e.nodes[m].prev = p
+
+	e.nodes[mPrev].next = m2
+	e.nodes[m2].prev = mPrev
+	e.nodes[m2].next = p2
+	e.nodes[p2].prev = m2
+	e.nodes[p2].next = pNext
+	e.nodes[pNext].prev = p2
+	e.count += 2
+}
+
+func (e *earClipper) cross(node int) float32 {
+	n := &e.nodes[node]
+	a, b, c := e.point(n.prev), e.point(node), e.point(n.next)
+	ab := vec2.Sub(b, a)
+	bc := vec2.Sub(c, b)
+	return vec2.Cross(&ab, &bc)
+}
+
+func (e *earClipper) isReflex(node int) bool {
+	return e.cross(node) < 0
+}
+
+// inSector returns if the direction from node to p is inside of the polygon angle at node.
+func (e *earClipper) inSector(node int, p *vec2.T) bool {
+	n := &e.nodes[node]
+	o := e.point(node)
+	u := vec2.Sub(e.point(n.next), o)
+	v := vec2.Sub(e.point(n.prev), o)
+	d := vec2.Sub(p, o)
+	if vec2.Cross(&u, &v) >= 0 {
+		return vec2.Cross(&u, &d) >= 0 && vec2.Cross(&d, &v) >= 0
+	}
+	return vec2.Cross(&u, &d) >= 0 || vec2.Cross(&d, &v) >= 0
+}
+
+// isEar returns if the triangle of node and its neighbors is left winding
+// and contains no other reflex nodes of the ring.
+func (e *earClipper) isEar(node int) bool {
+	if e.cross(node) <= 0 {
+		return false
+	}
+	n := &e.nodes[node]
+	a, b, c := e.point(n.prev), e.point(node), e.point(n.next)
+	for other := e.nodes[n.next].next; other != n.prev; other = e.nodes[other].next {
+		p := e.point(other)
+		if *p == *a || *p == *b || *p == *c {
+			continue
+		}
+		if e.isReflex(other) && pointInTriangle(p, a, b, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *earClipper) remove(node int) {
+	n := &e.nodes[node]
+	e.nodes[n.prev].next = n.next
+	e.nodes[n.next].prev = n.prev
+	if e.start == node {
+		e.start = n.next
+	}
+	e.count--
+}
+
+func (e *earClipper) clip() [][3]int {
+	triangles := make([][3]int, 0, e.count-2)
+	emit := func(node int) {
+		n := &e.nodes[node]
+		if e.cross(node) > 0 {
+			triangles = append(triangles, [3]int{e.nodes[n.prev].index, n.index, e.nodes[n.next].index})
+		}
+		e.remove(node)
+	}
+
+	node := e.start
+	stop := node
+	stuck := 0
+	for e.count > 3 {
+		next := e.nodes[node].next
+		if e.isEar(node) {
+			emit(node)
+			node = next
+			stop = node
+			stuck = 0
+			continue
+		}
+		node = next
+		if node != stop {
+			continue
+		}
+		// No ear found in a whole round
+		stuck++
+		if stuck == 1 {
+			// Remove points without area at collinear or duplicate points
+			for i := e.count; i > 0 && e.count > 3; i-- {
+				next := e.nodes[node].next
+				if e.cross(node) == 0 {
+					e.remove(node)
+				}
+				node = next
+			}
+		} else {
+			// Degenerated input, clip the least reflex node
+			best := node
+			for i, n := 0, node; i < e.count; i++ {
+				if e.cross(n) > e.cross(best) {
+					best = n
+				}
+				n = e.nodes[n].next
+			}
+			node = e.nodes[best].next
+			emit(best)
+			stuck = 0
+		}
+		stop = node
+	}
+	if e.count == 3 {
+		emit(e.start)
+	}
+	return triangles
+}
+
+// pointInTriangle returns if p is inside or on the border of the left winding triangle a, b, c.
+func pointInTriangle(p, a, b, c *vec2.T) bool {
+	return orient(a, b, p) >= 0 && orient(b, c, p) >= 0 && orient(c, a, p) >= 0
+}
+
+// orient returns a positive value if c is left of the line from a to b,
+// a negative value if it is right and zero if it is on the line.
+// The sign is exact, see inCircle().
+func orient(a, b, c *vec2.T) float64 {
+	return predicates.Orient2D(vec64(a), vec64(b), vec64(c))
+}
+
+// inCircle returns if d is inside of the circumcircle of the left winding triangle a, b, c.
+// The float32 coordinates are exactly representable as float64,
+// so the adaptive precision predicates of the float64 packages are exact for them.
+func inCircle(a, b, c, d *vec2.T) bool {
+	return predicates.InCircle(vec64(a), vec64(b), vec64(c), vec64(d)) > 0
+}
+
+// vec64 converts v to float64 without rounding.
+func vec64(v *vec2.T) *vec2d.T {
+	return &vec2d.T{float64(v[0]), float64(v[1])}
+}
+
+// delaunayFlip flips the edges of the left winding triangles that are not constrained
+// until all of them are locally Delaunay (Lawson's algorithm).
+func delaunayFlip(points []vec2.T, triangles [][3]int, constrained map[[2]int]bool) {
+	// Triangle and corner of every directed edge from corner to the next corner
+	type corner struct{ triangle, corner int }
+	edges := make(map[[2]int]corner, 3*len(triangles))
+	for t, tri := range triangles {
+		for i := range tri {
+			edges[[2]int{tri[i], tri[(i+1)%3]}] = corner{t, i}
+		}
+	}
+	// Seed in triangle order, the random order of the edges map would make the result nondeterministic
+	stack := make([][2]int, 0, len(edges))
+	for _, tri := range triangles {
+		for i := range tri {
+			edge := [2]int{tri[i], tri[(i+1)%3]}
+			if edge[0] < edge[1] && !constrained[edge] {
+				stack = append(stack, edge)
+			}
+		}
+	}
+
+	// Limit the number of flips in case rounding errors make flips oscillate
+	for flips := 0; len(stack) > 0 && flips < 4*len(triangles)*len(triangles)+16; {
+		edge := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		c0, ok0 := edges[edge]
+		c1, ok1 := edges[[2]int{edge[1], edge[0]}]
+		if !ok0 || !ok1 || constrained[edge] {
+			continue
+		}
+		// Triangles (a, b, c) and (b, a, d) share the edge from a to b
+		t0, t1 := &triangles[c0.triangle], &triangles[c1.triangle]
+		a, b := edge[0], edge[1]
+		c := t0[(c0.corner+2)%3]
+		d := t1[(c1.corner+2)%3]
+		if c == d || !inCircle(&points[a], &points[b], &points[c], &points[d]) {
+			continue
+		}
+		// The quad a, d, b, c is convex, replace the diagonal a-b by c-d
+		if orient(&points[a], &points[d], &points[c]) <= 0 || orient(&points[d], &points[b], &points[c]) <= 0 {
+			continue
+		}
+		flips++
+		delete(edges, [2]int{a, b})
+		delete(edges, [2]int{b, a})
+		*t0 = [3]int{a, d, c}
+		*t1 = [3]int{d, b, c}
+		for i := 0; i < 3; i++ {
+			edges[[2]int{t0[i], t0[(i+1)%3]}] = corner{c0.triangle, i}
+			edges[[2]int{t1[i], t1[(i+1)%3]}] = corner{c1.triangle, i}
+		}
+		stack = append(stack, [2]int{a, d}, [2]int{d, b}, [2]int{b, c}, [2]int{c, a})
+	}
+}
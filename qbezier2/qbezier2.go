@@ -5,7 +5,7 @@ package qbezier2
 import (
 	"fmt"
 
-	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/arclength"
 	"github.com/ungerik/go3d/vec2"
 )
 
@@ -42,10 +42,27 @@ func (bez *T) Tangent(t float32) vec2.T {
 }
 
 // Length returns the length of a quadratic bezier spline from P0 to t (0,1).
+// See LengthEps for details.
 func (bez *T) Length(t float32) float32 {
 	return Length(&bez.P0, &bez.P1, &bez.P2, t)
 }
 
+// LengthEps returns the length of a quadratic bezier spline from P0 to t (0,1)
+// computed with adaptive Gauss-Legendre quadrature up to the relative tolerance epsilon.
+func (bez *T) LengthEps(t, epsilon float32) float32 {
+	return LengthEps(&bez.P0, &bez.P1, &bez.P2, t, epsilon)
+}
+
+// ArcLengthTable returns a table that maps arc length distances on the spline
+// to t values, dividing the range (0,1) into segments intervals.
+// Use it to sample the spline at uniform spacing.
+func (bez *T) ArcLengthTable(segments int) arclength.Table {
+	return arclength.NewTable(func(t float32) float32 {
+		d := derivative(&bez.P0, &bez.P1, &bez.P2, t)
+		return d.Length()
+	}, segments)
+}
+
 // Point returns a point on a quadratic bezier spline at t (0,1).
 func Point(p0, p1, p2 *vec2.T, t float32) vec2.T {
 	t1 := 1.0 - t
@@ -66,6 +83,19 @@ func Point(p0, p1, p2 *vec2.T, t float32) vec2.T {
 
 // Tangent returns a tangent on a quadratic bezier spline at t (0,1).
 func Tangent(p0, p1, p2 *vec2.T, t float32) vec2.T {
+	result := derivative(p0, p1, p2, t)
+
+	if result[0] == 0 && result[1] == 0 {
+		fmt.Printf("zero tangent!  p0=%v, p1=%v, p2=%v, t=%v\n", p0, p1, p2, t)
+		panic("zero tangent of qbezier2")
+	}
+
+	return result
+}
+
+// derivative returns the first derivative of a quadratic bezier spline at t (0,1).
+// In contrast to Tangent it does not panic for a zero derivative.
+func derivative(p0, p1, p2 *vec2.T, t float32) vec2.T {
 	t1 := 1.0 - t
 
 	f := 2.0 * t1
@@ -77,33 +107,20 @@ func Tangent(p0, p1, p2 *vec2.T, t float32) vec2.T {
 	p2f.Scale(f)
 	result.Add(&p2f)
 
-	if result[0] == 0 && result[1] == 0 {
-		fmt.Printf("zero tangent!  p0=%v, p1=%v, p2=%v, t=%v\n", p0, p1, p2, t)
-		panic("zero tangent of qbezier2")
-	}
-
 	return result
 }
 
 // Length returns the length of a quadratic bezier spline from p0 to t (0,1).
-//
-// Note that although this calculation is accurate at t=0, 0.5, and 1 due
-// to the nature of quadratic curves, it is an approximation for other values of t.
+// See LengthEps for details.
 func Length(p0, p1, p2 *vec2.T, t float32) float32 {
-	ax := p0[0] - 2*p1[0] + p2[0]
-	ay := p0[1] - 2*p1[1] + p2[1]
-	bx := 2*p1[0] - 2*p0[0]
-	by := 2*p1[1] - 2*p0[1]
-
-	a := 4 * (ax*ax + ay*ay)
-	b := 4 * (ax*bx + ay*by)
-	c := bx*bx + by*by
-
-	abc := 2 * math.Sqrt(a+b+c)
-	a2 := math.Sqrt(a)
-	a32 := 2 * a * a2
-	c2 := 2 * math.Sqrt(c)
-	ba := b / a2
-
-	return t * (a32*abc + a2*b*(abc-c2) + (4*c*a-b*b)*math.Log((2*a2+ba+abc)/(ba+c2))) / (4 * a32)
+	return LengthEps(p0, p1, p2, t, arclength.DefaultTolerance)
+}
+
+// LengthEps returns the length of a quadratic bezier spline from p0 to t (0,1)
+// computed with adaptive Gauss-Legendre quadrature up to the relative tolerance epsilon.
+func LengthEps(p0, p1, p2 *vec2.T, t, epsilon float32) float32 {
+	return arclength.Integrate(func(t float32) float32 {
+		d := derivative(p0, p1, p2, t)
+		return d.Length()
+	}, 0, t, epsilon)
 }
@@ -0,0 +1,83 @@
+package qbezier2
+
+import (
+	"github.com/ungerik/go3d/bezier2"
+	"github.com/ungerik/go3d/vec2"
+)
+
+// Split splits the quadratic bezier spline at t (0,1) into two splines
+// using the de Casteljau algorithm.
+// left runs from P0 to Point(t) and right from Point(t) to P2.
+func (bez *T) Split(t float32) (left, right T) {
+	p01 := vec2.Interpolate(&bez.P0, &bez.P1, t)
+	p12 := vec2.Interpolate(&bez.P1, &bez.P2, t)
+	p012 := vec2.Interpolate(&p01, &p12, t)
+	left = T{bez.P0, p01, p012}
+	right = T{p012, p12, bez.P2}
+	return left, right
+}
+
+// SubCurve returns the part of the quadratic bezier spline from t0 to t1
+// as a new spline with the parameter range (0,1).
+// If t0 is greater than t1, the returned spline runs in the opposite direction.
+func (bez *T) SubCurve(t0, t1 float32) T {
+	return T{
+		bez.blossom(t0, t0),
+		bez.blossom(t0, t1),
+		bez.blossom(t1, t1),
+	}
+}
+
+// blossom evaluates the polar form of the spline,
+// which is the de Casteljau algorithm with a different t for every level.
+func (bez *T) blossom(t0, t1 float32) vec2.T {
+	p01 := vec2.Interpolate(&bez.P0, &bez.P1, t0)
+	p12 := vec2.Interpolate(&bez.P1, &bez.P2, t0)
+	return vec2.Interpolate(&p01, &p12, t1)
+}
+
+// Cubic returns the cubic bezier spline that describes exactly
+// the same curve as the quadratic spline (degree elevation).
+func (bez *T) Cubic() bezier2.T {
+	c1 := vec2.Interpolate(&bez.P0, &bez.P1, 2.0/3.0)
+	c2 := vec2.Interpolate(&bez.P2, &bez.P1, 2.0/3.0)
+	return bezier2.T{P0: bez.P0, P1: c1, P2: c2, P3: bez.P2}
+}
+
+// Extrema appends the parameters t in the open range (0,1) to dst
+// at which the x or y component of the spline has a local minimum or maximum,
+// which are the roots of the derivative components.
+// The appended parameters are sorted in ascending order.
+func (bez *T) Extrema(dst []float32) []float32 {
+	n := len(dst)
+	for i := range bez.P0 {
+		// Derivative divided by 2: (1-t)*d0 + t*d1
+		d0 := bez.P1[i] - bez.P0[i]
+		d1 := bez.P2[i] - bez.P1[i]
+		if d0 != d1 {
+			if t := d0 / (d0 - d1); t > 0 && t < 1 {
+				dst = append(dst, t)
+			}
+		}
+	}
+	if len(dst)-n == 2 && dst[n] > dst[n+1] {
+		dst[n], dst[n+1] = dst[n+1], dst[n]
+	}
+	return dst
+}
+
+// BoundingBox returns the tight axis aligned bounding box of the spline,
+// which can be smaller than the bounding box of the control points.
+func (bez *T) BoundingBox() vec2.Rect {
+	rect := vec2.Rect{
+		Min: vec2.Min(&bez.P0, &bez.P2),
+		Max: vec2.Max(&bez.P0, &bez.P2),
+	}
+	var buf [2]float32
+	for _, t := range bez.Extrema(buf[:0]) {
+		p := bez.Point(t)
+		rect.Min = vec2.Min(&rect.Min, &p)
+		rect.Max = vec2.Max(&rect.Max, &p)
+	}
+	return rect
+}
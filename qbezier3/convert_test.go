@@ -0,0 +1,46 @@
+package qbezier3
+
+import (
+	"testing"
+
+	"github.com/ungerik/go3d/bezier3"
+	"github.com/ungerik/go3d/vec3"
+)
+
+func TestCubic(t *testing.T) {
+	b := T{vec3.T{0, 0, 0}, vec3.T{1, 2, 3}, vec3.T{3, 0, 1}}
+	cubic := b.Cubic()
+	for _, s := range []float32{0, 0.2, 0.5, 0.9, 1} {
+		if got, want := cubic.Point(s), b.Point(s); !got.PracticallyEquals(&want, EPSILON) {
+			t.Errorf("cubic point at %f failed: got %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestFromCubic(t *testing.T) {
+	c := bezier3.T{P0: vec3.T{0, 0, 0}, P1: vec3.T{3, 2, -1}, P2: vec3.T{-1, 2, 2}, P3: vec3.T{2, 0, 0}}
+	for _, tolerance := range []float32{0.1, 0.01, 0.001} {
+		quads := FromCubic(&c, tolerance, nil)
+		if quads[0].P0 != c.P0 || quads[len(quads)-1].P2 != c.P3 {
+			t.Errorf("quadratic splines do not start and end at the end points: %v", quads)
+		}
+		n := float32(len(quads))
+		for s := float32(0); s <= 1; s += 1.0 / 256 {
+			i := min(int(s*n), len(quads)-1)
+			if i > 0 && quads[i].P0 != quads[i-1].P2 {
+				t.Errorf("quadratic spline %d does not start at the end of the previous one", i)
+			}
+			q := quads[i].Point(s*n - float32(i))
+			p := c.Point(s)
+			if d := vec3.Sub(&p, &q); d.Length() > tolerance+EPSILON {
+				t.Errorf("quadratic approximation with tolerance %f deviates %f at t=%f", tolerance, d.Length(), s)
+			}
+		}
+	}
+
+	b := T{vec3.T{0, 0, 0}, vec3.T{1, 2, 3}, vec3.T{3, 0, 1}}
+	cubic := b.Cubic()
+	if quads := FromCubic(&cubic, 0.0001, nil); len(quads) != 1 || !quads[0].P1.PracticallyEquals(&b.P1, EPSILON) {
+		t.Errorf("elevated quadratic spline %v should convert back to itself, got %v", b, quads)
+	}
+}
@@ -0,0 +1,87 @@
+package qbezier3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ungerik/go3d/vec3"
+)
+
+const EPSILON = 0.0001
+
+func TestParseAndString(t *testing.T) {
+	original := T{vec3.T{0, 0, 0}, vec3.T{1, 0, 1}, vec3.T{2, 0.5, 0}}
+	parsed, err := Parse(original.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != original {
+		t.Errorf("Parse(String()) failed, got %v, want %v", parsed, original)
+	}
+}
+
+func TestPoint(t *testing.T) {
+	b := T{vec3.T{0, 0, 0}, vec3.T{1, 0, 1}, vec3.T{2, 0, 0}}
+	if got, want := b.Point(0), (vec3.T{0, 0, 0}); got != want {
+		t.Errorf("quadratic bezier point at t=0 failed, got %v, want %v", got, want)
+	}
+	if got, want := b.Point(1), (vec3.T{2, 0, 0}); got != want {
+		t.Errorf("quadratic bezier point at t=1 failed, got %v, want %v", got, want)
+	}
+	if got, want := b.Point(0.5), (vec3.T{1, 0, 0.5}); got != want {
+		t.Errorf("quadratic bezier point at t=0.5 failed, got %v, want %v", got, want)
+	}
+}
+
+func TestTangent(t *testing.T) {
+	b := T{vec3.T{0, 0, 0}, vec3.T{1, 0, 1}, vec3.T{2, 0, 0}}
+	if got, want := b.Tangent(0), (vec3.T{2, 0, 2}); got != want {
+		t.Errorf("quadratic bezier tangent at t=0 failed, got %v, want %v", got, want)
+	}
+	if got, want := b.Tangent(1), (vec3.T{2, 0, -2}); got != want {
+		t.Errorf("quadratic bezier tangent at t=1 failed, got %v, want %v", got, want)
+	}
+	if got, want := b.Tangent(0.5), (vec3.T{2, 0, 0}); got != want {
+		t.Errorf("quadratic bezier tangent at t=0.5 failed, got %v, want %v", got, want)
+	}
+	// Control point at the start
+	degenerated := T{vec3.T{0, 0, 0}, vec3.T{0, 0, 0}, vec3.T{2, 0, 0}}
+	if got := degenerated.Tangent(0); got != (vec3.T{}) {
+		t.Errorf("quadratic bezier tangent with coincident control points failed, got %v, want zero", got)
+	}
+}
+
+func TestSecondDerivativeAndCurvature(t *testing.T) {
+	b := T{vec3.T{0, 0, 0}, vec3.T{1, 0, 1}, vec3.T{2, 0, 0}}
+	for _, tVal := range []float32{0, 0.5, 1} {
+		if got, want := b.SecondDerivative(tVal), (vec3.T{0, 0, -4}); got != want {
+			t.Errorf("quadratic bezier second derivative at t=%f failed, got %v, want %v", tVal, got, want)
+		}
+	}
+	// First derivative (2,0,0) and second derivative (0,0,-4) at the apex
+	if got := b.Curvature(0.5); math.Abs(float64(got-1)) > EPSILON {
+		t.Errorf("quadratic bezier curvature at t=0.5 failed, got %f, want 1", got)
+	}
+	// Curvature is highest at the apex of a symmetric parabola
+	if b.Curvature(0.25) >= b.Curvature(0.5) {
+		t.Errorf("curvature at t=0.25 should be less than at the apex")
+	}
+}
+
+func TestLength(t *testing.T) {
+	// Same parabola as the qbezier2 test, but in the xz-plane
+	b := T{vec3.T{0, 0, 0}, vec3.T{1, 0, 1}, vec3.T{2, 0, 0}}
+	const want = 2.2955871493867663
+	if got := b.Length(1); math.Abs(float64(got)-want) > EPSILON {
+		t.Errorf("quadratic bezier length failed, got %f, want %f", got, want)
+	}
+	if got := b.Length(0.5); math.Abs(float64(got)-want/2) > EPSILON {
+		t.Errorf("quadratic bezier length at t=0.5 failed, got %f, want %f", got, want/2)
+	}
+
+	table := b.ArcLengthTable(8)
+	params := table.UniformParams(3, nil)
+	if math.Abs(float64(params[1]-0.5)) > EPSILON {
+		t.Errorf("middle uniform parameter of symmetric spline should be 0.5, got %f", params[1])
+	}
+}
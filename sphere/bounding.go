@@ -0,0 +1,179 @@
+package sphere
+
+import (
+	"math/rand"
+
+	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/vec3"
+)
+
+// tolerance is the relative error of the squared radius
+// that is accepted as containment while constructing the minimal sphere.
+const tolerance = 1e-5
+
+// FromPoints returns a sphere containing all points using Ritter's algorithm,
+// which is fast but usually a few percent larger than the minimal sphere.
+// See: Jack Ritter, An Efficient Bounding Sphere, Graphics Gems, 1990
+func FromPoints(points []vec3.T) T {
+	if len(points) == 0 {
+		return T{}
+	}
+	a := farthest(points, &points[0])
+	b := farthest(points, a)
+	sphere := circumsphere([]vec3.T{*a, *b})
+	for i := range points {
+		p := &points[i]
+		if distance := vec3.Distance(&sphere.Center, p); distance > sphere.Radius {
+			// Grow the sphere just enough to contain p and the far side of the old sphere
+			radius := (sphere.Radius + distance) / 2
+			sphere.Center = vec3.Interpolate(&sphere.Center, p, (radius-sphere.Radius)/distance)
+			sphere.Radius = radius
+		}
+	}
+	sphere.enclose(points)
+	return sphere
+}
+
+// MinimalFromPoints returns the smallest sphere containing all points
+// using Welzl's algorithm with the points in random order,
+// which takes expected linear time.
+// See: Emo Welzl, Smallest enclosing disks (balls and ellipsoids), 1991
+func MinimalFromPoints(points []vec3.T) T {
+	if len(points) == 0 {
+		return T{}
+	}
+	shuffled := append([]vec3.T(nil), points...)
+	random := rand.New(rand.NewSource(1))
+	random.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	sphere := minimal(shuffled, nil)
+	sphere.enclose(points)
+	return sphere
+}
+
+// minimal returns the smallest sphere containing points
+// with the boundary points on its surface.
+func minimal(points, boundary []vec3.T) T {
+	sphere := circumsphere(boundary)
+	if len(boundary) == 4 {
+		return sphere
+	}
+	for i := range points {
+		if !sphere.nearlyContains(&points[i]) {
+			sphere = minimal(points[:i], append(boundary[:len(boundary):len(boundary)], points[i]))
+		}
+	}
+	return sphere
+}
+
+// nearlyContains returns if p is inside of the sphere allowing for rounding errors.
+// A negative radius marks the empty sphere that contains no points.
+func (sphere *T) nearlyContains(p *vec3.T) bool {
+	if sphere.Radius < 0 {
+		return false
+	}
+	r2 := sphere.Radius * sphere.Radius
+	return vec3.SquareDistance(&sphere.Center, p) <= r2+tolerance*r2
+}
+
+// enclose enlarges the radius of the sphere to contain all points
+// despite rounding errors of its construction.
+func (sphere *T) enclose(points []vec3.T) {
+	for i := range points {
+		if !sphere.ContainsPoint(&points[i]) {
+			sphere.Radius = vec3.Distance(&sphere.Center, &points[i])
+			for !sphere.ContainsPoint(&points[i]) {
+				sphere.Radius = math.Nextafter(sphere.Radius, math.Inf(1))
+			}
+		}
+	}
+}
+
+// farthest returns the point with the largest distance from p.
+func farthest(points []vec3.T, p *vec3.T) *vec3.T {
+	result, maxDistance := p, float32(0)
+	for i := range points {
+		if d := vec3.SquareDistance(p, &points[i]); d > maxDistance {
+			result, maxDistance = &points[i], d
+		}
+	}
+	return result
+}
+
+// circumsphere returns the smallest sphere with up to four points on its surface,
+// the empty sphere with a negative radius for no points.
+// For degenerated points the smallest sphere through a subset of them
+// that contains all of them is returned.
+func circumsphere(points []vec3.T) T {
+	switch len(points) {
+	case 0:
+		return T{Radius: -1}
+	case 1:
+		return T{Center: points[0]}
+	case 2:
+		return T{
+			Center: vec3.Interpolate(&points[0], &points[1], 0.5),
+			Radius: vec3.Distance(&points[0], &points[1]) / 2,
+		}
+	}
+	// Calculated relative to the first point to reduce rounding errors
+	a := &points[0]
+	b := vec3.Sub(&points[1], a)
+	c := vec3.Sub(&points[2], a)
+	var offset vec3.T
+	if len(points) == 3 {
+		bc := vec3.Cross(&b, &c)
+		denominator := 2 * bc.LengthSqr()
+		if denominator == 0 {
+			return circumsphereOfSubset(points)
+		}
+		// ((|b|² c - |c|² b) x (b x c)) / (2 |b x c|²)
+		u := c.Scaled(b.LengthSqr())
+		v := b.Scaled(c.LengthSqr())
+		u.Sub(&v)
+		offset = vec3.Cross(&u, &bc)
+		offset.Scale(1 / denominator)
+	} else {
+		d := vec3.Sub(&points[3], a)
+		cd, db, bc := vec3.Cross(&c, &d), vec3.Cross(&d, &b), vec3.Cross(&b, &c)
+		denominator := 2 * vec3.Dot(&b, &cd)
+		if denominator == 0 {
+			return circumsphereOfSubset(points)
+		}
+		// (|b|² (c x d) + |c|² (d x b) + |d|² (b x c)) / (2 b · (c x d))
+		cd.Scale(b.LengthSqr())
+		db.Scale(c.LengthSqr())
+		bc.Scale(d.LengthSqr())
+		offset = vec3.Add(&cd, &db)
+		offset.Add(&bc)
+		offset.Scale(1 / denominator)
+	}
+	return T{Center: vec3.Add(a, &offset), Radius: offset.Length()}
+}
+
+// circumsphereOfSubset returns the smallest circumsphere of all points but one
+// that contains all points, or the largest one if rounding errors prevent that.
+func circumsphereOfSubset(points []vec3.T) T {
+	result, largest := T{Radius: -1}, T{Radius: -1}
+	for skip := range points {
+		subset := make([]vec3.T, 0, len(points)-1)
+		subset = append(subset, points[:skip]...)
+		subset = append(subset, points[skip+1:]...)
+		sphere := circumsphere(subset)
+		if sphere.Radius > largest.Radius {
+			largest = sphere
+		}
+		containsAll := true
+		for i := range points {
+			containsAll = containsAll && sphere.nearlyContains(&points[i])
+		}
+		if containsAll && (result.Radius < 0 || sphere.Radius < result.Radius) {
+			result = sphere
+		}
+	}
+	if result.Radius < 0 {
+		return largest
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+// Package sphere contains a float32 type T for bounding spheres of 3D points
+// with a fast approximation after Ritter and the exact minimal sphere after Welzl.
+// See: https://en.wikipedia.org/wiki/Bounding_sphere
+package sphere
+
+import (
+	math "github.com/chewxy/math32"
+	"github.com/ungerik/go3d/mat3"
+	"github.com/ungerik/go3d/mat4"
+	"github.com/ungerik/go3d/vec3"
+)
+
+// T is a sphere defined by its Center and Radius.
+type T struct {
+	Center vec3.T
+	Radius float32
+}
+
+// FromBox returns the smallest sphere containing box.
+func FromBox(box *vec3.Box) T {
+	diagonal := box.Diagonal()
+	return T{Center: box.Center(), Radius: diagonal.Length() / 2}
+}
+
+// Box returns the bounding box of the sphere.
+func (sphere *T) Box() vec3.Box {
+	r := vec3.T{sphere.Radius, sphere.Radius, sphere.Radius}
+	return vec3.Box{Min: vec3.Sub(&sphere.Center, &r), Max: vec3.Add(&sphere.Center, &r)}
+}
+
+// Volume returns the volume of the sphere.
+func (sphere *T) Volume() float32 {
+	return 4 / 3.0 * math.Pi * sphere.Radius * sphere.Radius * sphere.Radius
+}
+
+// ContainsPoint returns if p is inside or on the surface of the sphere.
+func (sphere *T) ContainsPoint(p *vec3.T) bool {
+	return vec3.SquareDistance(&sphere.Center, p) <= sphere.Radius*sphere.Radius
+}
+
+// ContainsSphere returns if other is completely inside of the sphere.
+func (sphere *T) ContainsSphere(other *T) bool {
+	if other.Radius > sphere.Radius {
+		return false
+	}
+	d := sphere.Radius - other.Radius
+	return vec3.SquareDistance(&sphere.Center, &other.Center) <= d*d
+}
+
+// Intersects returns if the sphere and other overlap or touch.
+func (sphere *T) Intersects(other *T) bool {
+	r := sphere.Radius + other.Radius
+	return vec3.SquareDistance(&sphere.Center, &other.Center) <= r*r
+}
+
+// IntersectsBox returns if the sphere and box overlap or touch.
+func (sphere *T) IntersectsBox(box *vec3.Box) bool {
+	closest := sphere.Center.Clamped(&box.Min, &box.Max)
+	return sphere.ContainsPoint(&closest)
+}
+
+// Join enlarges the sphere to the smallest sphere that contains also other.
+func (sphere *T) Join(other *T) *T {
+	if sphere.ContainsSphere(other) {
+		return sphere
+	}
+	if other.ContainsSphere(sphere) {
+		*sphere = *other
+		return sphere
+	}
+	distance := vec3.Distance(&sphere.Center, &other.Center)
+	radius := (distance + sphere.Radius + other.Radius) / 2
+	// Move the center towards other, so that the far sides of both spheres touch the result
+	sphere.Center = vec3.Interpolate(&sphere.Center, &other.Center, (radius-sphere.Radius)/distance)
+	sphere.Radius = radius
+	return sphere
+}
+
+// Joined returns the smallest sphere containing both a and b.
+func Joined(a, b *T) T {
+	joined := *a
+	joined.Join(b)
+	return joined
+}
+
+// Transform transforms the sphere by the affine matrix mat.
+// The radius is scaled by the largest scaling of mat,
+// which is the spectral norm of its upper 3x3 matrix,
+// so the result contains the transformed sphere
+// which is an ellipsoid for non uniform scaling or shearing.
+func (sphere *T) Transform(mat *mat4.T) *T {
+	mat.TransformVec3(&sphere.Center)
+	// The largest eigenvalue of M^T * M is the square of the largest scaling
+	var mtm mat3.T
+	for i := 0; i < 3; i++ {
+		a := vec3.T{mat[i][0], mat[i][1], mat[i][2]}
+		for j := 0; j < 3; j++ {
+			b := vec3.T{mat[j][0], mat[j][1], mat[j][2]}
+			mtm[i][j] = vec3.Dot(&a, &b)
+		}
+	}
+	values, _ := mtm.EigenSymmetric()
+	sphere.Radius *= math.Sqrt(max(values[0], 0))
+	return sphere
+}
+
+// Transformed returns a copy of the sphere transformed by the affine matrix mat.
+func (sphere *T) Transformed(mat *mat4.T) T {
+	result := *sphere
+	result.Transform(mat)
+	return result
+}